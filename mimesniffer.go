@@ -5,62 +5,275 @@ package mimesniffer
 
 import (
 	"bytes"
+	"compress/flate"
 	"encoding/binary"
+	"encoding/json"
+	"io"
+	"io/ioutil"
 	"mime"
 	"net/http"
+	"path"
 	"strings"
+	"unicode/utf16"
 )
 
 var (
-	defaultSniffers = map[string]func([]byte) bool{
-		"application/epub+zip":              applicationEPUBZip,
-		"application/font-sfnt":             applicationFontSFNT,
-		"application/font-woff":             applicationFontWOFF,
-		"application/msword":                applicationMSWord,
-		"application/rtf":                   applicationRTF,
-		"application/vnd.ms-cab-compressed": applicationVNDMSCABCompressed,
-		"application/vnd.ms-excel":          applicationVNDMSExcel,
-		"application/vnd.ms-powerpoint":     applicationVNDMSPowerpoint,
-		"application/vnd.openxmlformats-officedocument.presentationml.presentation": applicationVNDOpenXMLFormatsOfficeDocumentPresentationMLPresentation,
-		"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet":         applicationVNDOpenXMLFormatsOfficeDocumentSpreadsheeetMLSheet,
-		"application/vnd.openxmlformats-officedocument.wordprocessingml.document":   applicationVNDOpenXMLFormatsOfficeDocumentWordprocessingMLDocument,
-		"application/x-7z-compressed":                                               applicationX7ZCompressed,
-		"application/x-bzip2":                                                       applicationXBzip2,
-		"application/x-compress":                                                    applicationXCompress,
-		"application/x-deb":                                                         applicationXDEB,
-		"application/x-executable":                                                  applicationXExecutable,
-		"application/x-google-chrome-extension":                                     applicationXGoogleChromeExtension,
-		"application/x-lzip":                                                        applicationXLzip,
-		"application/x-msdownload":                                                  applicationXMSDownload,
-		"application/x-nintendo-nes-rom":                                            applicationXNintendoNESROM,
-		"application/x-rpm":                                                         applicationXRPM,
-		"application/x-shockwave-flash":                                             applicationXShockwaveFlash,
-		"application/x-sqlite3":                                                     applicationXSQLite3,
-		"application/x-tar":                                                         applicationXTar,
-		"application/x-unix-archive":                                                applicationXUNIXArchive,
-		"application/x-xz":                                                          applicationXXZ,
-		"audio/aac":                                                                 audioAAC,
-		"audio/amr":                                                                 audioAMR,
-		"audio/m4a":                                                                 audioM4A,
-		"audio/ogg":                                                                 audioOgg,
-		"audio/x-flac":                                                              audioXFLAC,
-		"audio/x-wav":                                                               audioXWAV,
-		"image/jp2":                                                                 imageJP2,
-		"image/tiff":                                                                imageTIFF,
-		"image/vnd.adobe.photoshop":                                                 imageVNDAdobePhotoshop,
-		"image/x-canon-cr2":                                                         imageXCanonCR2,
-		"video/mpeg":                                                                videoMPEG,
-		"video/quicktime":                                                           videoQuickTime,
-		"video/x-flv":                                                               videoXFLV,
-		"video/x-m4v":                                                               videoXM4V,
-		"video/x-matroska":                                                          videoXMatroska,
-		"video/x-ms-wmv":                                                            videoXMSWMV,
-		"video/x-msvideo":                                                           videoXMSVideo,
+	// defaultSniffers is the list of the default sniffers, evaluated in order
+	// until the first match. It has two sections, each sorted by MIME type:
+	// the specific sniffers, then the generic sniffers that match a superset
+	// of what some specific ones match (e.g. the "application/x-archive" also
+	// matches every "application/vnd.debian.binary-package"). Two overlapping
+	// sniffers may only share a section if the narrower one sorts first (e.g.
+	// the "application/msix" and the "application/vnd.ms-appx"). Anything left
+	// unmatched falls back to the http.DetectContentType, so every ZIP-based
+	// sniffer takes precedence over the "application/zip".
+	//
+	// The known overlaps are checked by the TestDefaultSniffersPrecedence.
+	defaultSniffers = []defaultSniffer{
+		{"application/atom+xml", applicationAtomXML},
+		{"application/dicom", applicationDICOM},
+		{"application/epub+zip", applicationEPUBZip},
+		{"application/font-sfnt", applicationFontSFNT},
+		{"application/font-woff", applicationFontWOFF},
+		{"application/mbox", applicationMbox},
+		{"application/msix", applicationMSIX},
+		{"application/onenote", applicationOneNote},
+		{"application/pkcs10", applicationPKCS10},
+		{"application/pkcs7-mime", applicationPKCS7MIME},
+		{"application/pkcs8", applicationPKCS8},
+		{"application/pkcs8-encrypted", applicationPKCS8Encrypted},
+		{"application/pkix-cert", applicationPKIXCert},
+		{"application/pkix-crl", applicationPKIXCRL},
+		{"application/rss+xml", applicationRSSXML},
+		{"application/rtf", applicationRTF},
+		{"application/soap+xml", applicationSOAPXML},
+		{"application/sql", applicationSQL},
+		{"application/vnd.amazon.ebook", applicationVNDAmazonEbook},
+		{"application/vnd.android.dex", applicationVNDAndroidDEX},
+		{"application/vnd.android.package-archive", applicationVNDAndroidPackageArchive},
+		{"application/vnd.apache.arrow.file", applicationVNDApacheArrowFile},
+		{"application/vnd.apple.keynote", applicationVNDAppleKeynote},
+		{"application/vnd.apple.mpegurl", applicationVNDAppleMPEGURL},
+		{"application/vnd.apple.numbers", applicationVNDAppleNumbers},
+		{"application/vnd.apple.pages", applicationVNDApplePages},
+		{"application/vnd.comicbook-rar", applicationVNDComicBookRAR},
+		{"application/vnd.debian.binary-package", applicationVNDDebianBinaryPackage},
+		{"application/vnd.google-earth.kmz", applicationVNDGoogleEarthKMZ},
+		{"application/vnd.ms-appx", applicationVNDMSAppx},
+		{"application/vnd.ms-cab-compressed", applicationVNDMSCABCompressed},
+		{"application/vnd.ms-fontobject", applicationVNDMSFontObject},
+		{"application/vnd.ms-outlook", applicationVNDMSOutlook},
+		{"application/vnd.ms-outlook-pst", applicationVNDMSOutlookPST},
+		{"application/vnd.ms-project", applicationVNDMSProject},
+		{"application/vnd.nuget.package", applicationVNDNuGetPackage},
+		{"application/vnd.oasis.opendocument.graphics", applicationVNDOASISOpenDocumentGraphics},
+		{"application/vnd.oasis.opendocument.presentation", applicationVNDOASISOpenDocumentPresentation},
+		{"application/vnd.oasis.opendocument.spreadsheet", applicationVNDOASISOpenDocumentSpreadsheet},
+		{"application/vnd.oasis.opendocument.text", applicationVNDOASISOpenDocumentText},
+		{"application/vnd.openxmlformats-officedocument.presentationml.presentation", applicationVNDOpenXMLFormatsOfficeDocumentPresentationMLPresentation},
+		{"application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", applicationVNDOpenXMLFormatsOfficeDocumentSpreadsheeetMLSheet},
+		{"application/vnd.openxmlformats-officedocument.wordprocessingml.document", applicationVNDOpenXMLFormatsOfficeDocumentWordprocessingMLDocument},
+		{"application/vsix", applicationVSIX},
+		{"application/wasm", applicationWasm},
+		{"application/x-7z-compressed", applicationX7ZCompressed},
+		{"application/x-ace-compressed", applicationXACECompressed},
+		{"application/x-bzip2", applicationXBzip2},
+		{"application/x-compress", applicationXCompress},
+		{"application/x-cpio", applicationXCPIO},
+		{"application/x-doom-wad", applicationXDoomWAD},
+		{"application/x-dotnet-assembly", applicationXDotNetAssembly},
+		{"application/x-efi-application", applicationXEFIApplication},
+		{"application/x-executable", applicationXExecutable},
+		{"application/x-fictionbook+xml", applicationXFictionBookXML},
+		{"application/x-firmware", applicationXFirmware},
+		{"application/x-font-bdf", applicationXFontBDF},
+		{"application/x-font-pcf", applicationXFontPCF},
+		{"application/x-font-type1", applicationXFontType1},
+		{"application/x-gamecube-rom", applicationXGameCubeROM},
+		{"application/x-gba-rom", applicationXGBAROM},
+		{"application/x-genesis-rom", applicationXGenesisROM},
+		{"application/x-git-object", applicationXGitObject},
+		{"application/x-git-pack", applicationXGitPack},
+		{"application/x-git-pack-index", applicationXGitPackIndex},
+		{"application/x-google-chrome-extension", applicationXGoogleChromeExtension},
+		{"application/x-iso9660-image", applicationXISO9660Image},
+		{"application/x-itunes-ipa", applicationXITunesIPA},
+		{"application/x-leveldb-current", applicationXLevelDBCurrent},
+		{"application/x-leveldb-manifest", applicationXLevelDBManifest},
+		{"application/x-leveldb-sst", applicationXLevelDBSST},
+		{"application/x-lua-bytecode", applicationXLuaBytecode},
+		{"application/x-lz4", applicationXLZ4},
+		{"application/x-lzip", applicationXLzip},
+		{"application/x-lzop", applicationXLzop},
+		{"application/x-mach-binary", applicationXMachBinary},
+		{"application/x-msi", applicationXMSI},
+		{"application/x-mslib", applicationXMSLib},
+		{"application/x-n64-rom", applicationXN64ROM},
+		{"application/x-ndjson", applicationXNDJSON},
+		{"application/x-netcdf", applicationXNetCDF},
+		{"application/x-nintendo-ds-rom", applicationXNintendoDSROM},
+		{"application/x-nintendo-nes-rom", applicationXNintendoNESROM},
+		{"application/x-python-bytecode", applicationXPythonBytecode},
+		{"application/x-qemu-disk", applicationXQEMUDisk},
+		{"application/x-rpm", applicationXRPM},
+		{"application/x-shockwave-flash", applicationXShockwaveFlash},
+		{"application/x-snes-rom", applicationXSNESROM},
+		{"application/x-sqlite3", applicationXSQLite3},
+		{"application/x-sqlite3-journal", applicationXSQLite3Journal},
+		{"application/x-sqlite3-wal", applicationXSQLite3WAL},
+		{"application/x-static-library", applicationXStaticLibrary},
+		{"application/x-subrip", applicationXSubRip},
+		{"application/x-tar", applicationXTar},
+		{"application/x-tex-pk", applicationXTeXPK},
+		{"application/x-vhd", applicationXVHD},
+		{"application/x-vhdx", applicationXVHDX},
+		{"application/x-virtualbox-vdi", applicationXVirtualBoxVDI},
+		{"application/x-vmdk", applicationXVMDK},
+		{"application/x-wii-rom", applicationXWiiROM},
+		{"application/x-x509-ca-cert", applicationXX509CACert},
+		{"application/x-xar", applicationXXAR},
+		{"application/x-xpinstall", applicationXXPInstall},
+		{"application/x-xz", applicationXXZ},
+		{"application/x-zip-compressed-fb2", applicationXZipCompressedFB2},
+		{"application/xhtml+xml", applicationXHTMLXML},
+		{"audio/aac", audioAAC},
+		{"audio/ac3", audioAC3},
+		{"audio/aiff", audioAIFF},
+		{"audio/amr", audioAMR},
+		{"audio/amr-wb", audioAMRWB},
+		{"audio/basic", audioBasic},
+		{"audio/eac3", audioEAC3},
+		{"audio/m4a", audioM4A},
+		{"audio/mpeg", audioMPEG},
+		{"audio/opus", audioOpus},
+		{"audio/vnd.dts", audioVNDDTS},
+		{"audio/webm", audioWebM},
+		{"audio/x-caf", audioXCAF},
+		{"audio/x-flac", audioXFLAC},
+		{"audio/x-it", audioXIT},
+		{"audio/x-matroska", audioXMatroska},
+		{"audio/x-mod", audioXMod},
+		{"audio/x-ms-wma", audioXMSWMA},
+		{"audio/x-musepack", audioXMusepack},
+		{"audio/x-s3m", audioXS3M},
+		{"audio/x-speex", audioXSpeex},
+		{"audio/x-tta", audioXTTA},
+		{"audio/x-wav", audioXWAV},
+		{"audio/x-wavpack", audioXWavPack},
+		{"audio/x-xm", audioXXM},
+		{"font/collection", fontCollection},
+		{"image/bpg", imageBPG},
+		{"image/emf", imageEMF},
+		{"image/jp2", imageJP2},
+		{"image/qoi", imageQOI},
+		{"image/vnd.adobe.photoshop", imageVNDAdobePhotoshop},
+		{"image/wmf", imageWMF},
+		{"image/x-adobe-dng", imageXAdobeDNG},
+		{"image/x-canon-cr2", imageXCanonCR2},
+		{"image/x-canon-cr3", imageXCanonCR3},
+		{"image/x-fuji-raf", imageXFujiRAF},
+		{"image/x-nikon-nef", imageXNikonNEF},
+		{"image/x-olympus-orf", imageXOlympusORF},
+		{"image/x-panasonic-rw2", imageXPanasonicRW2},
+		{"image/x-pcx", imageXPCX},
+		{"image/x-pentax-pef", imageXPentaxPEF},
+		{"image/x-sony-arw", imageXSonyARW},
+		{"image/x-xbitmap", imageXXBitmap},
+		{"image/x-xpixmap", imageXXPixmap},
+		{"message/rfc822", messageRFC822},
+		{"text/calendar", textCalendar},
+		{"text/vcard", textVCard},
+		{"text/vtt", textVTT},
+		{"video/h264", videoH264},
+		{"video/h265", videoH265},
+		{"video/mp2t", videoMP2T},
+		{"video/mp4", videoMP4},
+		{"video/mpeg", videoMPEG},
+		{"video/vnd.rn-realmedia", videoVNDRNRealMedia},
+		{"video/x-flv", videoXFLV},
+		{"video/x-ivf", videoXIVF},
+		{"video/x-ivf; codecs=av01", videoXIVFAV1},
+		{"video/x-ivf; codecs=vp09", videoXIVFVP9},
+		{"video/x-ivf; codecs=vp8", videoXIVFVP8},
+		{"video/x-m4v", videoXM4V},
+		{"video/x-ms-asf", videoXMSASF},
+		{"video/x-ms-wmv", videoXMSWMV},
+		{"video/x-msvideo", videoXMSVideo},
+		{"video/x-yuv4mpeg", videoXYUV4MPEG},
+
+		// Generic sniffers
+		{"application/java-archive", applicationJavaArchive},
+		{"application/msword", applicationMSWord},
+		{"application/pdf", applicationPDF},
+		{"application/pgp-encrypted", applicationPGPEncrypted},
+		{"application/pgp-keys", applicationPGPKeys},
+		{"application/pgp-signature", applicationPGPSignature},
+		{"application/vnd.comicbook+zip", applicationVNDComicBookZip},
+		{"application/vnd.ms-excel", applicationVNDMSExcel},
+		{"application/vnd.ms-powerpoint", applicationVNDMSPowerpoint},
+		{"application/vnd.rar", applicationVNDRAR},
+		{"application/x-archive", applicationXArchive},
+		{"application/x-coff-object", applicationXCOFFObject},
+		{"application/x-mobipocket-ebook", applicationXMobipocketEbook},
+		{"application/x-msdownload", applicationXMSDownload},
+		{"application/x-orc", applicationXORC},
+		{"application/x-pem-file", applicationXPEMFile},
+		{"application/x-quake-pak", applicationXQuakePAK},
+		{"application/x-tex-tfm", applicationXTeXTFM},
+		{"application/x-yaml", applicationXYAML},
+		{"application/xml", applicationXML},
+		{"application/zlib", applicationZlib},
+		{"audio/ogg", audioOgg},
+		{"audio/x-mpegurl", audioXMPEGURL},
+		{"image/tiff", imageTIFF},
+		{"text/csv", textCSV},
+		{"text/markdown", textMarkdown},
+		{"text/tab-separated-values", textTabSeparatedValues},
+		{"video/quicktime", videoQuickTime},
+		{"video/x-matroska", videoXMatroska},
 	}
 
 	registeredSniffers = map[string]func([]byte) bool{}
+
+	// nintendoLogoPrefix is the beginning of the Nintendo logo bitmap
+	// embedded in the headers of the GBA and Nintendo DS ROMs.
+	nintendoLogoPrefix = []byte{
+		0x24, 0xff, 0xae, 0x51, 0x69, 0x9a, 0xa2, 0x21,
+	}
+)
+
+// hdf5SuperblockLimit is the maximum offset of an HDF5 superblock that will be
+// looked for, and hdf5RootGroupWindow is the number of bytes from the superblock
+// that will be looked at for the attributes of the root group.
+const (
+	hdf5SuperblockLimit = 1 << 20
+	hdf5RootGroupWindow = 4096
+)
+
+// zipEntriesLimit and zipEntriesWindow bound the number of local file entries
+// and the number of bytes of a ZIP archive that will be looked at. The entries
+// that identify a ZIP-based format are conventionally placed first.
+const (
+	zipEntriesLimit  = 32
+	zipEntriesWindow = 1 << 20
 )
 
+// zipEntryDataLimit is the maximum number of bytes of the uncompressed data of
+// a ZIP entry that will be looked at, which is enough to find the root element
+// of an XML entry or the relationship types of a "_rels/.rels" entry.
+const zipEntryDataLimit = 4096
+
+// defaultSniffer is a default MIME type sniffer.
+type defaultSniffer struct {
+	mimeType string
+
+	// sniff is a func([]byte) bool, or a func([]zipEntry) bool for the
+	// ZIP-based MIME types, or a func([][]byte) bool for the line-based
+	// text MIME types, so that the ZIP entries and the text lines are only
+	// parsed once.
+	sniff interface{}
+}
+
 // Register registers the sniffer for the mimeType. Invalid MIME types will be
 // silently dropped.
 func Register(mimeType string, sniffer func([]byte) bool) {
@@ -73,8 +286,9 @@ func Register(mimeType string, sniffer func([]byte) bool) {
 }
 
 // Sniff sniffs the MIME type of the b. It considers at most the first 512 bytes
-// of the b. It returns "application/octet-stream" if it cannot determine a more
-// specific one.
+// of the b for most MIME types, but some of them (such as disc images) can only
+// be determined by looking further. It returns "application/octet-stream" if it
+// cannot determine a more specific one.
 //
 // The returned MIME type is always valid.
 func Sniff(b []byte) string {
@@ -88,15 +302,198 @@ func Sniff(b []byte) string {
 		}
 	}
 
-	for mt, s := range defaultSniffers {
-		if s(b) {
-			return mt
+	return sniff(b)
+}
+
+// SniffBrotli reports whether the b is a Brotli compressed stream.
+//
+// Brotli streams have no magic number, so it only checks whether the b begins
+// with a valid stream header followed by a valid meta-block header, and whether
+// no other MIME type can be determined for the b. Since this is prone to false
+// positives, it is not used by the Sniff unless registered:
+//
+//	mimesniffer.Register("application/x-brotli", mimesniffer.SniffBrotli)
+func SniffBrotli(b []byte) bool {
+	pos := 0
+	bits := func(n int) (uint64, bool) {
+		if pos+n > 8*len(b) {
+			return 0, false
+		}
+
+		var v uint64
+		for i := 0; i < n; i++ {
+			v |= uint64(b[pos/8]>>uint(pos%8)&0x01) << uint(i)
+			pos++
+		}
+
+		return v, true
+	}
+
+	// Stream header (WBITS)
+	if v, ok := bits(1); !ok {
+		return false
+	} else if v == 1 {
+		if v, ok = bits(3); !ok {
+			return false
+		} else if v == 0 {
+			if v, ok = bits(3); !ok || v == 1 {
+				return false
+			}
+		}
+	}
+
+	// Meta-block header
+	isLast, ok := bits(1)
+	if !ok {
+		return false
+	}
+
+	if isLast == 1 {
+		if isLastEmpty, ok := bits(1); !ok {
+			return false
+		} else if isLastEmpty == 1 {
+			return pos <= 8 && len(b) == 1
+		}
+	}
+
+	mNibbles, ok := bits(2)
+	if !ok {
+		return false
+	}
+
+	if mNibbles == 3 { // Metadata
+		if reserved, ok := bits(1); !ok || reserved != 0 {
+			return false
+		}
+	} else {
+		n := 4 + int(mNibbles)
+		mLen, ok := bits(4 * n)
+		if !ok || n > 4 && mLen>>uint(4*(n-1)) == 0 {
+			return false
+		}
+
+		if isLast == 0 {
+			if isUncompressed, ok := bits(1); !ok {
+				return false
+			} else if isUncompressed == 1 {
+				if pos%8 != 0 {
+					if padding, ok := bits(8 - pos%8); !ok || padding != 0 {
+						return false
+					}
+				}
+			}
+		}
+	}
+
+	return sniff(b) == "application/octet-stream"
+}
+
+// SniffSelfExtractingArchive reports whether the b is a self-extracting
+// archive, which is a PE executable with an embedded ZIP, RAR or 7z archive.
+//
+// The embedded archive is usually appended to the executable, so it can only be
+// found if the b is large enough to contain the beginning of it. Since any
+// executable may happen to contain such data, it is not used by the Sniff
+// unless registered:
+//
+//	mimesniffer.Register(
+//		"application/x-msdownload; sfx=true",
+//		mimesniffer.SniffSelfExtractingArchive,
+//	)
+func SniffSelfExtractingArchive(b []byte) bool {
+	if len(b) < 0x40 || !applicationXMSDownload(b) {
+		return false
+	}
+
+	pe := int(binary.LittleEndian.Uint32(b[0x3c:0x40]))
+	if pe < 0x40 || pe > len(b)-4 || string(b[pe:pe+4]) != "PE\x00\x00" {
+		return false
+	}
+
+	for i := pe + 4; i < len(b); i++ {
+		switch b[i] {
+		case 'P':
+			if len(zipEntries(b[i:])) > 0 {
+				return true
+			}
+		case 'R':
+			if applicationVNDRAR(b[i:]) {
+				return true
+			}
+		case '7':
+			if applicationX7ZCompressed(b[i:]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// sniff is like the Sniff, but without considering the registered sniffers.
+func sniff(b []byte) string {
+	entries := zipEntries(b)
+	lines, text := textLines(b)
+	for _, s := range defaultSniffers {
+		switch sniff := s.sniff.(type) {
+		case func([]byte) bool:
+			if sniff(b) {
+				return s.mimeType
+			}
+		case func([]zipEntry) bool:
+			if len(entries) > 0 && sniff(entries) {
+				return s.mimeType
+			}
+		case func([][]byte) bool:
+			if text && sniff(lines) {
+				return s.mimeType
+			}
 		}
 	}
 
 	return http.DetectContentType(b)
 }
 
+// applicationAtomXML reports whether the b's MIME type is
+// "application/atom+xml".
+func applicationAtomXML(b []byte) bool {
+	name, ns, ok := xmlRootElement(b)
+	return ok && name == "feed" && ns == "http://www.w3.org/2005/Atom"
+}
+
+// applicationDICOM reports whether the b's MIME type is "application/dicom".
+func applicationDICOM(b []byte) bool {
+	if len(b) > 131 &&
+		b[128] == 0x44 &&
+		b[129] == 0x49 &&
+		b[130] == 0x43 &&
+		b[131] == 0x4d {
+		return true
+	}
+
+	// Without the preamble, look for at least two data elements of the
+	// group 0008 in ascending order, encoded with the implicit VR little
+	// endian transfer syntax.
+	var o, prev uint64
+	for i := 0; i < 2; i++ {
+		if o+8 > uint64(len(b)) ||
+			binary.LittleEndian.Uint16(b[o:o+2]) != 0x0008 {
+			return false
+		}
+
+		element := uint64(binary.LittleEndian.Uint16(b[o+2 : o+4]))
+		length := uint64(binary.LittleEndian.Uint32(b[o+4 : o+8]))
+		if i > 0 && element <= prev || length%2 != 0 || length > 0x400 {
+			return false
+		}
+
+		prev = element
+		o += 8 + length
+	}
+
+	return true
+}
+
 // applicationEPUBZip reports whether the b's MIME type is
 // "application/epub+zip".
 func applicationEPUBZip(b []byte) bool {
@@ -173,46 +570,54 @@ func applicationFontWOFF(b []byte) bool {
 			b[7] == 0x00
 }
 
-// applicationMSWord reports whether the b's MIME type is "application/msword".
-func applicationMSWord(b []byte) bool {
-	return len(b) > 7 &&
-		b[0] == 0xd0 &&
-		b[1] == 0xcf &&
-		b[2] == 0x11 &&
-		b[3] == 0xe0 &&
-		b[4] == 0xa1 &&
-		b[5] == 0xb1 &&
-		b[6] == 0x1a &&
-		b[7] == 0xe1
+// applicationJavaArchive reports whether the entries' MIME type is
+// "application/java-archive".
+func applicationJavaArchive(entries []zipEntry) bool {
+	for _, e := range entries {
+		if string(e.name) == "META-INF/MANIFEST.MF" ||
+			bytes.HasPrefix(e.extra, []byte{0xfe, 0xca}) {
+			return true
+		}
+	}
+
+	return false
 }
 
-// applicationRTF reports whether the b's MIME type is "application/rtf".
-func applicationRTF(b []byte) bool {
-	return len(b) > 4 &&
-		b[0] == 0x7b &&
-		b[1] == 0x5c &&
-		b[2] == 0x72 &&
-		b[3] == 0x74 &&
-		b[4] == 0x66
+// applicationMbox reports whether the lines' MIME type is "application/mbox".
+func applicationMbox(lines [][]byte) bool {
+	return len(lines) > 1 &&
+		bytes.HasPrefix(lines[0], []byte("From ")) &&
+		mailHeaders(lines[1:]) > 0
 }
 
-// applicationVNDMSCABCompressed reports whether the b's MIME type is
-// "application/vnd.ms-cab-compressed".
-func applicationVNDMSCABCompressed(b []byte) bool {
-	return len(b) > 3 &&
-		(b[0] == 0x4d &&
-			b[1] == 0x53 &&
-			b[2] == 0x43 &&
-			b[3] == 0x46 ||
-			b[0] == 0x49 &&
-				b[1] == 0x53 &&
-				b[2] == 0x63 &&
-				b[3] == 0x28)
+// applicationMSIX reports whether the entries' MIME type is "application/msix".
+//
+// MSIX packages share the same layout as APPX packages, but their block maps
+// use the 2015 (or later) schema, which introduced the hash method selection.
+func applicationMSIX(entries []zipEntry) bool {
+	if !applicationVNDMSAppx(entries) {
+		return false
+	}
+
+	for _, e := range entries {
+		if string(e.name) == "AppxBlockMap.xml" {
+			name, ns, ok := xmlRootElement(zipEntryData(e))
+			return ok &&
+				name == "BlockMap" &&
+				strings.HasPrefix(ns, "http://schemas.microsoft.com/appx/") &&
+				ns != "http://schemas.microsoft.com/appx/2010/blockmap"
+		}
+	}
+
+	return false
 }
 
-// applicationVNDMSExcel reports whether the b's MIME type is
-// "application/vnd.ms-excel".
-func applicationVNDMSExcel(b []byte) bool {
+// applicationMSWord reports whether the b's MIME type is "application/msword".
+func applicationMSWord(b []byte) bool {
+	// The directory may be out of reach of the b, so any CFB file that is not
+	// known to be created by another Office application is taken as a Word
+	// document.
+	app := cfbOfficeApplication(b)
 	return len(b) > 7 &&
 		b[0] == 0xd0 &&
 		b[1] == 0xcf &&
@@ -221,660 +626,4278 @@ func applicationVNDMSExcel(b []byte) bool {
 		b[4] == 0xa1 &&
 		b[5] == 0xb1 &&
 		b[6] == 0x1a &&
-		b[7] == 0xe1
+		b[7] == 0xe1 &&
+		(app == "" || app == "word")
 }
 
-// applicationVNDMSPowerpoint reports whether the b's MIME type is
-// "application/vnd.ms-powerpoint".
-func applicationVNDMSPowerpoint(b []byte) bool {
-	return len(b) > 7 &&
-		b[0] == 0xd0 &&
-		b[1] == 0xcf &&
-		b[2] == 0x11 &&
-		b[3] == 0xe0 &&
-		b[4] == 0xa1 &&
-		b[5] == 0xb1 &&
-		b[6] == 0x1a &&
-		b[7] == 0xe1
+// applicationOneNote reports whether the b's MIME type is
+// "application/onenote".
+func applicationOneNote(b []byte) bool {
+	return bytes.HasPrefix(b, []byte{
+		0xe4, 0x52, 0x5c, 0x7b, 0x8c, 0xd8, 0xa7, 0x4d,
+		0xae, 0xb1, 0x53, 0x78, 0xd0, 0x29, 0x96, 0xd3,
+	}) || bytes.HasPrefix(b, []byte{
+		0xa1, 0x2f, 0xff, 0x43, 0xd9, 0xef, 0x76, 0x4c,
+		0x9e, 0xe2, 0x10, 0xea, 0x57, 0x22, 0x76, 0x5f,
+	})
 }
 
-// applicationVNDOpenXMLFormatsOfficeDocumentPresentationMLPresentation reports
-// whether the b's MIME type is
-// "application/vnd.openxmlformats-officedocument.presentationml.presentation".
-func applicationVNDOpenXMLFormatsOfficeDocumentPresentationMLPresentation(
-	b []byte,
-) bool {
-	sign := []byte{'P', 'K', 0x03, 0x04}
-	pptx := []byte("ppt/")
-	ctxml := []byte("[Content_Types].xml")
-	rels := []byte("_rels/.rels")
-	bl, sl, l, cl, rl := len(b), len(sign), len(pptx), len(ctxml), len(rels)
+// applicationPDF reports whether the b's MIME type is "application/pdf".
+func applicationPDF(b []byte) bool {
+	if len(b) > 1024 {
+		b = b[:1024]
+	}
 
-	if bl < sl || !bytes.Equal(b[:sl], sign) {
-		return false
+	i := bytes.Index(b, []byte("%PDF-"))
+	if i > 0 {
+		// Do not mistake archives storing PDF files for PDF files.
+		for _, sign := range [][]byte{
+			[]byte("PK\x03\x04"),
+			[]byte("Rar!\x1a\x07"),
+			[]byte("!<arch>\n"),
+			[]byte("07070"),
+		} {
+			if bytes.HasPrefix(b, sign) {
+				return false
+			}
+		}
+
+		if len(b) > 262 && bytes.Equal(b[257:262], []byte("ustar")) {
+			return false
+		}
+
+		// Do not mistake text mentioning PDF headers for PDF files
+		// either, unless the header is on a line of its own.
+		text := false
+		for _, c := range b[:i] {
+			if c == ' ' || c == '\t' || c == '\n' || c == '\r' {
+				continue
+			}
+
+			if c < 0x20 || c > 0x7e {
+				text = false
+				break
+			}
+
+			text = true
+		}
+
+		if text && (b[i-1] != '\n' && b[i-1] != '\r' ||
+			len(b) <= i+8 ||
+			b[i+8] != '\n' && b[i+8] != '\r') {
+			return false
+		}
 	}
 
-	if bl < l+0x1e && bytes.Equal(b[0x1e:l+0x1e], pptx) {
+	return i >= 0 &&
+		len(b) > i+7 &&
+		b[i+5] >= '1' &&
+		b[i+5] <= '9' &&
+		b[i+6] == '.' &&
+		b[i+7] >= '0' &&
+		b[i+7] <= '9'
+}
+
+// applicationPGPEncrypted reports whether the b's MIME type is
+// "application/pgp-encrypted".
+func applicationPGPEncrypted(b []byte) bool {
+	if pemLabel(b) == "PGP MESSAGE" {
 		return true
 	}
 
-	if (bl < cl+0x1e || !bytes.Equal(b[0x1e:cl+0x1d], ctxml)) &&
-		(bl < rl+0x1e || !bytes.Equal(b[0x1e:rl+0x1d], rels)) {
+	tag, body, ok := pgpPacket(b)
+	if !ok || len(body) < 2 {
 		return false
 	}
 
-	start := int(binary.BigEndian.Uint32(b[18:22]) + 49)
-	end := start + 6000
-	if end > bl {
-		end = bl
+	switch tag {
+	case 1: // Public-Key Encrypted Session Key
+		return body[0] == 3 || body[0] == 6
+	case 3: // Symmetric-Key Encrypted Session Key
+		return (body[0] == 4 || body[0] == 5 || body[0] == 6) &&
+			body[1] >= 1 &&
+			body[1] <= 13
+	case 18: // Symmetrically Encrypted and Integrity Protected Data
+		return body[0] == 1 || body[0] == 2
 	}
 
-	if start >= end {
-		return false
-	}
+	return false
+}
 
-	i := bytes.Index(b[start:end], sign)
-	if i == -1 {
-		return false
+// applicationPGPKeys reports whether the b's MIME type is
+// "application/pgp-keys".
+func applicationPGPKeys(b []byte) bool {
+	if label := pemLabel(b); label == "PGP PUBLIC KEY BLOCK" ||
+		label == "PGP PRIVATE KEY BLOCK" {
+		return true
 	}
 
-	start += i + 4 + 26
-	end = start + 6000
-	if end > bl {
-		end = bl
+	tag, body, ok := pgpPacket(b)
+	if !ok || (tag != 5 && tag != 6) || len(body) < 6 {
+		return false // Not a Secret-Key or a Public-Key packet
 	}
 
-	if start >= end {
-		return false
+	switch body[0] {
+	case 2, 3:
+		return len(body) > 7 && pgpPublicKeyAlgorithm(body[7])
+	case 4, 5, 6:
+		return pgpPublicKeyAlgorithm(body[5])
 	}
 
-	i = bytes.Index(b[start:end], sign)
-	if i == -1 {
-		return false
-	}
+	return false
+}
 
-	start += i + 4 + 26
-	if bl < l+start && bytes.Equal(b[start:l+start], pptx) {
+// applicationPGPSignature reports whether the b's MIME type is
+// "application/pgp-signature".
+func applicationPGPSignature(b []byte) bool {
+	if label := pemLabel(b); label == "PGP SIGNATURE" ||
+		label == "PGP SIGNED MESSAGE" {
 		return true
 	}
 
-	start += 26
-	end = start + 6000
-	if end > bl {
-		end = bl
-	}
-
-	if start >= end {
-		return false
+	tag, body, ok := pgpPacket(b)
+	if !ok || tag != 2 || len(body) < 3 {
+		return false // Not a Signature packet
 	}
 
-	i = bytes.Index(b[start:end], sign)
-	if i == -1 {
-		return false
+	switch body[0] {
+	case 3:
+		return body[1] == 5 && len(body) > 15 && pgpPublicKeyAlgorithm(body[15])
+	case 4, 5, 6:
+		return pgpPublicKeyAlgorithm(body[2])
 	}
 
-	start += i + 4 + 26
-
-	return bl < l+start && bytes.Equal(b[start:l+start], pptx)
+	return false
 }
 
-// applicationVNDOpenXMLFormatsOfficeDocumentSpreadsheeetMLSheet reports whether
-// the b's MIME type is
-// "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet".
-func applicationVNDOpenXMLFormatsOfficeDocumentSpreadsheeetMLSheet(
-	b []byte,
-) bool {
-	sign := []byte{'P', 'K', 0x03, 0x04}
-	xlsx := []byte("xl/")
-	ctxml := []byte("[Content_Types].xml")
-	rels := []byte("_rels/.rels")
-	bl, sl, l, cl, rl := len(b), len(sign), len(xlsx), len(ctxml), len(rels)
+// applicationPKCS10 reports whether the b's MIME type is "application/pkcs10".
+func applicationPKCS10(b []byte) bool {
+	label := pemLabel(b)
+	return label == "CERTIFICATE REQUEST" ||
+		label == "NEW CERTIFICATE REQUEST"
+}
 
-	if bl < sl || !bytes.Equal(b[:sl], sign) {
-		return false
-	}
+// applicationPKCS7MIME reports whether the b's MIME type is
+// "application/pkcs7-mime".
+func applicationPKCS7MIME(b []byte) bool {
+	label := pemLabel(b)
+	return label == "PKCS7" || label == "CMS"
+}
 
-	if bl < l+0x1e && bytes.Equal(b[0x1e:l+0x1e], xlsx) {
-		return true
-	}
+// applicationPKCS8 reports whether the b's MIME type is "application/pkcs8".
+func applicationPKCS8(b []byte) bool {
+	return pemLabel(b) == "PRIVATE KEY"
+}
 
-	if (bl < cl+0x1e || !bytes.Equal(b[0x1e:cl+0x1d], ctxml)) &&
-		(bl < rl+0x1e || !bytes.Equal(b[0x1e:rl+0x1d], rels)) {
-		return false
-	}
+// applicationPKCS8Encrypted reports whether the b's MIME type is
+// "application/pkcs8-encrypted".
+func applicationPKCS8Encrypted(b []byte) bool {
+	return pemLabel(b) == "ENCRYPTED PRIVATE KEY"
+}
 
-	start := int(binary.BigEndian.Uint32(b[18:22]) + 49)
-	end := start + 6000
-	if end > bl {
-		end = bl
-	}
+// applicationPKIXCert reports whether the b's MIME type is
+// "application/pkix-cert".
+func applicationPKIXCert(b []byte) bool {
+	return pemLabel(b) == "CERTIFICATE"
+}
 
-	if start >= end {
-		return false
-	}
+// applicationPKIXCRL reports whether the b's MIME type is
+// "application/pkix-crl".
+func applicationPKIXCRL(b []byte) bool {
+	return x509Kind(b) == "crl" || pemLabel(b) == "X509 CRL"
+}
 
-	i := bytes.Index(b[start:end], sign)
-	if i == -1 {
-		return false
-	}
+// applicationRSSXML reports whether the b's MIME type is "application/rss+xml".
+func applicationRSSXML(b []byte) bool {
+	name, _, ok := xmlRootElement(b)
+	return ok && name == "rss"
+}
 
-	start += i + 4 + 26
-	end = start + 6000
-	if end > bl {
-		end = bl
-	}
+// applicationRTF reports whether the b's MIME type is "application/rtf".
+func applicationRTF(b []byte) bool {
+	return len(b) > 4 &&
+		b[0] == 0x7b &&
+		b[1] == 0x5c &&
+		b[2] == 0x72 &&
+		b[3] == 0x74 &&
+		b[4] == 0x66
+}
 
-	if start >= end {
-		return false
-	}
+// applicationSOAPXML reports whether the b's MIME type is
+// "application/soap+xml".
+func applicationSOAPXML(b []byte) bool {
+	name, ns, ok := xmlRootElement(b)
+	return ok &&
+		name == "Envelope" &&
+		(ns == "http://www.w3.org/2003/05/soap-envelope" ||
+			ns == "http://schemas.xmlsoap.org/soap/envelope/")
+}
 
-	i = bytes.Index(b[start:end], sign)
-	if i == -1 {
+// applicationSQL reports whether the lines' MIME type is "application/sql".
+func applicationSQL(lines [][]byte) bool {
+	if len(lines) == 0 {
 		return false
 	}
 
-	start += i + 4 + 26
-	if bl < l+start && bytes.Equal(b[start:l+start], xlsx) {
+	if bytes.HasPrefix(lines[0], []byte("-- MySQL dump")) ||
+		bytes.HasPrefix(lines[0], []byte("-- MariaDB dump")) ||
+		len(lines) > 1 &&
+			bytes.Equal(lines[0], []byte("--")) &&
+			bytes.HasPrefix(
+				lines[1],
+				[]byte("-- PostgreSQL database dump"),
+			) {
 		return true
 	}
 
-	start += 26
-	end = start + 6000
-	if end > bl {
-		end = bl
-	}
+	started, tables := false, 0
+	for _, l := range lines {
+		l = bytes.TrimSpace(l)
+		if len(l) == 0 ||
+			bytes.HasPrefix(l, []byte("--")) ||
+			bytes.HasPrefix(l, []byte("/*")) &&
+				(bytes.HasSuffix(l, []byte("*/")) ||
+					bytes.HasSuffix(l, []byte("*/;"))) {
+			continue
+		}
 
-	if start >= end {
-		return false
-	}
+		if textHasPrefixFold(l, "CREATE TABLE ") ||
+			textHasPrefixFold(l, "INSERT INTO ") {
+			tables++
+		} else if !started {
+			statement := false
+			for _, k := range []string{
+				"ALTER ",
+				"BEGIN",
+				"CREATE ",
+				"DROP ",
+				"LOCK TABLES ",
+				"SET ",
+				"START TRANSACTION",
+				"USE ",
+			} {
+				if textHasPrefixFold(l, k) {
+					statement = true
+					break
+				}
+			}
 
-	i = bytes.Index(b[start:end], sign)
-	if i == -1 {
-		return false
-	}
+			if !statement {
+				return false
+			}
+		}
 
-	start += i + 4 + 26
+		started = true
+	}
 
-	return bl < l+start && bytes.Equal(b[start:l+start], xlsx)
+	return tables > 1
 }
 
-// applicationVNDOpenXMLFormatsOfficeDocumentWordprocessingMLDocument reports
-// whether the b's MIME type is
-// "application/vnd.openxmlformats-officedocument.wordprocessingml.document".
-func applicationVNDOpenXMLFormatsOfficeDocumentWordprocessingMLDocument(
-	b []byte,
-) bool {
-	sign := []byte{'P', 'K', 0x03, 0x04}
-	word := []byte("word/")
-	ctxml := []byte("[Content_Types].xml")
-	rels := []byte("_rels/.rels")
-	bl, sl, l, cl, rl := len(b), len(sign), len(word), len(ctxml), len(rels)
-
-	if bl < sl || !bytes.Equal(b[:sl], sign) {
+// applicationVNDAmazonEbook reports whether the b's MIME type is
+// "application/vnd.amazon.ebook".
+func applicationVNDAmazonEbook(b []byte) bool {
+	r := mobiRecord0(b)
+	if len(r) < 40 || string(r[16:20]) != "MOBI" {
 		return false
 	}
 
-	if bl < l+0x1e && bytes.Equal(b[0x1e:l+0x1e], word) {
+	if binary.BigEndian.Uint16(r[12:14]) == 2 || // Mobipocket DRM
+		binary.BigEndian.Uint32(r[36:40]) >= 8 { // KF8
 		return true
 	}
 
-	if (bl < cl+0x1e || !bytes.Equal(b[0x1e:cl+0x1d], ctxml)) &&
-		(bl < rl+0x1e || !bytes.Equal(b[0x1e:rl+0x1d], rels)) {
+	if len(r) < 132 || binary.BigEndian.Uint32(r[128:132])&0x40 == 0 {
 		return false
 	}
 
-	start := int(binary.BigEndian.Uint32(b[18:22]) + 49)
-	end := start + 6000
-	if end > bl {
-		end = bl
-	}
-
-	if start >= end {
+	o := 16 + uint64(binary.BigEndian.Uint32(r[20:24]))
+	if o+12 > uint64(len(r)) || string(r[o:o+4]) != "EXTH" {
 		return false
 	}
 
-	i := bytes.Index(b[start:end], sign)
-	if i == -1 {
-		return false
-	}
+	n := binary.BigEndian.Uint32(r[o+8 : o+12])
+	for o += 12; n > 0 && o+8 <= uint64(len(r)); n-- {
+		recordType := binary.BigEndian.Uint32(r[o : o+4])
+		recordLength := uint64(binary.BigEndian.Uint32(r[o+4 : o+8]))
+		if recordType == 121 && // KF8 boundary offset
+			recordLength >= 12 &&
+			o+12 <= uint64(len(r)) &&
+			binary.BigEndian.Uint32(r[o+8:o+12]) != 0xffffffff {
+			return true
+		}
 
-	start += i + 4 + 26
-	end = start + 6000
-	if end > bl {
-		end = bl
-	}
+		if recordLength < 8 {
+			break
+		}
 
-	if start >= end {
-		return false
+		o += recordLength
 	}
 
-	i = bytes.Index(b[start:end], sign)
-	if i == -1 {
+	return false
+}
+
+// applicationVNDAndroidDEX reports whether the b's MIME type is
+// "application/vnd.android.dex".
+func applicationVNDAndroidDEX(b []byte) bool {
+	return len(b) > 7 &&
+		b[0] == 0x64 &&
+		b[1] == 0x65 &&
+		(b[2] == 0x78 || b[2] == 0x79) &&
+		b[3] == 0x0a &&
+		b[4] >= 0x30 &&
+		b[4] <= 0x39 &&
+		b[5] >= 0x30 &&
+		b[5] <= 0x39 &&
+		b[6] >= 0x30 &&
+		b[6] <= 0x39 &&
+		b[7] == 0x00
+}
+
+// applicationVNDAndroidPackageArchive reports whether the entries' MIME type is
+// "application/vnd.android.package-archive".
+func applicationVNDAndroidPackageArchive(entries []zipEntry) bool {
+	return zipContains(
+		entries,
+		"AndroidManifest.xml",
+		"classes.dex",
+		"resources.arsc",
+	)
+}
+
+// applicationVNDApacheArrowFile reports whether the b's MIME type is
+// "application/vnd.apache.arrow.file".
+func applicationVNDApacheArrowFile(b []byte) bool {
+	return len(b) > 5 &&
+		b[0] == 0x41 &&
+		b[1] == 0x52 &&
+		b[2] == 0x52 &&
+		b[3] == 0x4f &&
+		b[4] == 0x57 &&
+		b[5] == 0x31 ||
+		len(b) > 3 &&
+			b[0] == 0x46 &&
+			b[1] == 0x45 &&
+			b[2] == 0x41 &&
+			b[3] == 0x31
+}
+
+// applicationVNDAppleKeynote reports whether the entries' MIME type is
+// "application/vnd.apple.keynote".
+func applicationVNDAppleKeynote(entries []zipEntry) bool {
+	return iWorkApplication(entries) == "keynote"
+}
+
+// applicationVNDAppleMPEGURL reports whether the lines' MIME type is
+// "application/vnd.apple.mpegurl".
+func applicationVNDAppleMPEGURL(lines [][]byte) bool {
+	if !m3u(lines) {
 		return false
 	}
 
-	start += i + 4 + 26
-	if bl < l+start && bytes.Equal(b[start:l+start], word) {
-		return true
+	for _, l := range lines {
+		if bytes.HasPrefix(l, []byte("#EXT-X-")) {
+			return true
+		}
 	}
 
-	start += 26
-	end = start + 6000
-	if end > bl {
-		end = bl
-	}
+	return false
+}
 
-	if start >= end {
-		return false
-	}
+// applicationVNDAppleNumbers reports whether the entries' MIME type is
+// "application/vnd.apple.numbers".
+func applicationVNDAppleNumbers(entries []zipEntry) bool {
+	return iWorkApplication(entries) == "numbers"
+}
 
-	i = bytes.Index(b[start:end], sign)
-	if i == -1 {
-		return false
+// applicationVNDApplePages reports whether the entries' MIME type is
+// "application/vnd.apple.pages".
+func applicationVNDApplePages(entries []zipEntry) bool {
+	return iWorkApplication(entries) == "pages"
+}
+
+// applicationVNDComicBookZip reports whether the entries' MIME type is
+// "application/vnd.comicbook+zip".
+func applicationVNDComicBookZip(entries []zipEntry) bool {
+	names := make([][]byte, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.name)
 	}
 
-	start += i + 4 + 26
+	return comicBook(names)
+}
 
-	return bl < l+start && bytes.Equal(b[start:l+start], word)
+// applicationVNDComicBookRAR reports whether the b's MIME type is
+// "application/vnd.comicbook-rar".
+func applicationVNDComicBookRAR(b []byte) bool {
+	return comicBook(rarFileNames(b))
 }
 
-// applicationX7ZCompressed reports whether the b's MIME type is
-// "application/x-7z-compressed".
-func applicationX7ZCompressed(b []byte) bool {
-	return len(b) > 5 &&
-		b[0] == 0x37 &&
-		b[1] == 0x7a &&
-		b[2] == 0xbc &&
-		b[3] == 0xaf &&
-		b[4] == 0x27 &&
-		b[5] == 0x1c
+// applicationVNDDebianBinaryPackage reports whether the b's MIME type is
+// "application/vnd.debian.binary-package".
+func applicationVNDDebianBinaryPackage(b []byte) bool {
+	names, _ := arMemberNames(b)
+	return len(names) > 0 && names[0] == "debian-binary"
 }
 
-// applicationXBzip2 reports whether the b's MIME type is "application/x-bzip2".
-func applicationXBzip2(b []byte) bool {
-	return len(b) > 2 &&
-		b[0] == 0x42 &&
-		b[1] == 0x5a &&
-		b[2] == 0x68
+// applicationVNDGoogleEarthKMZ reports whether the entries' MIME type is
+// "application/vnd.google-earth.kmz".
+func applicationVNDGoogleEarthKMZ(entries []zipEntry) bool {
+	return zipContains(entries, "doc.kml")
 }
 
-// applicationXCompress reports whether the b's MIME type is
-// "application/x-compress".
-func applicationXCompress(b []byte) bool {
-	return len(b) > 1 &&
-		(b[0] == 0x1f &&
-			b[1] == 0xa0 ||
-			b[0] == 0x1f &&
-				b[1] == 0x9d)
+// applicationVNDMSAppx reports whether the entries' MIME type is
+// "application/vnd.ms-appx".
+func applicationVNDMSAppx(entries []zipEntry) bool {
+	return zipContains(entries, "AppxManifest.xml", "AppxBlockMap.xml")
 }
 
-// applicationXDEB reports whether the b's MIME type is "application/x-deb".
-func applicationXDEB(b []byte) bool {
-	return len(b) > 20 &&
-		b[0] == 0x21 &&
-		b[1] == 0x3c &&
-		b[2] == 0x61 &&
-		b[3] == 0x72 &&
-		b[4] == 0x63 &&
-		b[5] == 0x68 &&
-		b[6] == 0x3e &&
-		b[7] == 0x0a &&
-		b[8] == 0x64 &&
-		b[9] == 0x65 &&
-		b[10] == 0x62 &&
-		b[11] == 0x69 &&
-		b[12] == 0x61 &&
-		b[13] == 0x6e &&
-		b[14] == 0x2d &&
-		b[15] == 0x62 &&
-		b[16] == 0x69 &&
-		b[17] == 0x6e &&
-		b[18] == 0x61 &&
-		b[19] == 0x72 &&
-		b[20] == 0x79
+// applicationVNDMSCABCompressed reports whether the b's MIME type is
+// "application/vnd.ms-cab-compressed".
+func applicationVNDMSCABCompressed(b []byte) bool {
+	return len(b) > 3 &&
+		(b[0] == 0x4d &&
+			b[1] == 0x53 &&
+			b[2] == 0x43 &&
+			b[3] == 0x46 ||
+			b[0] == 0x49 &&
+				b[1] == 0x53 &&
+				b[2] == 0x63 &&
+				b[3] == 0x28)
 }
 
-// applicationXExecutable reports whether the b's MIME type is
-// "application/x-executable".
-func applicationXExecutable(b []byte) bool {
-	return len(b) > 52 &&
-		b[0] == 0x7f &&
-		b[1] == 0x45 &&
-		b[2] == 0x4c &&
-		b[3] == 0x46
+// applicationVNDMSExcel reports whether the b's MIME type is
+// "application/vnd.ms-excel".
+func applicationVNDMSExcel(b []byte) bool {
+	return cfbOfficeApplication(b) == "excel"
 }
 
-// applicationXGoogleChromeExtension reports whether the b's MIME type is
-// "application/x-google-chrome-extension".
-func applicationXGoogleChromeExtension(b []byte) bool {
-	return len(b) > 3 &&
-		b[0] == 0x43 &&
-		b[1] == 0x72 &&
-		b[2] == 0x32 &&
-		b[3] == 0x34
+// applicationVNDMSFontObject reports whether the b's MIME type is
+// "application/vnd.ms-fontobject".
+func applicationVNDMSFontObject(b []byte) bool {
+	if len(b) < 36 || b[34] != 0x4c || b[35] != 0x50 {
+		return false
+	}
+
+	switch binary.LittleEndian.Uint32(b[8:12]) { // Version
+	case 0x00010000, 0x00020001, 0x00020002:
+	default:
+		return false
+	}
+
+	// EOTSize must cover the header as well as the FontDataSize.
+	return binary.LittleEndian.Uint32(b[0:4]) >
+		binary.LittleEndian.Uint32(b[4:8])
 }
 
-// applicationXLzip reports whether the b's MIME type is "application/x-lzip".
-func applicationXLzip(b []byte) bool {
-	return len(b) > 3 &&
-		b[0] == 0x4c &&
-		b[1] == 0x5a &&
-		b[2] == 0x49 &&
-		b[3] == 0x50
+// applicationVNDMSOutlook reports whether the b's MIME type is
+// "application/vnd.ms-outlook".
+func applicationVNDMSOutlook(b []byte) bool {
+	for _, e := range cfbDirectoryEntries(b) {
+		name := cfbEntryName(e)
+		if name == "__properties_version1.0" ||
+			strings.HasPrefix(name, "__substg1.0_") {
+			return true
+		}
+	}
+
+	return false
 }
 
-// applicationXMSDownload reports whether the b's MIME type is
-// "application/x-msdownload".
-func applicationXMSDownload(b []byte) bool {
-	return len(b) > 1 &&
-		b[0] == 0x4d &&
-		b[1] == 0x5a
+// applicationVNDMSOutlookPST reports whether the b's MIME type is
+// "application/vnd.ms-outlook-pst".
+func applicationVNDMSOutlookPST(b []byte) bool {
+	if len(b) < 12 ||
+		b[0] != 0x21 ||
+		b[1] != 0x42 ||
+		b[2] != 0x44 ||
+		b[3] != 0x4e ||
+		b[8] != 0x53 ||
+		(b[9] != 0x4d && b[9] != 0x4f) {
+		return false
+	}
+
+	switch binary.LittleEndian.Uint16(b[10:12]) {
+	case 14, 15, 23, 36:
+		return true
+	}
+
+	return false
 }
 
-// applicationXNintendoNESROM reports whether the b's MIME type is
-// "application/x-nintendo-nes-rom".
-func applicationXNintendoNESROM(b []byte) bool {
-	return len(b) > 3 &&
-		b[0] == 0x4e &&
-		b[1] == 0x45 &&
-		b[2] == 0x53 &&
-		b[3] == 0x1a
+// applicationVNDMSPowerpoint reports whether the b's MIME type is
+// "application/vnd.ms-powerpoint".
+func applicationVNDMSPowerpoint(b []byte) bool {
+	return cfbOfficeApplication(b) == "powerpoint"
 }
 
-// applicationXRPM reports whether the b's MIME type is "application/x-rpm".
-func applicationXRPM(b []byte) bool {
-	return len(b) > 96 &&
-		b[0] == 0xed &&
-		b[1] == 0xab &&
-		b[2] == 0xee &&
-		b[3] == 0xdb
+// applicationVNDMSProject reports whether the b's MIME type is
+// "application/vnd.ms-project".
+func applicationVNDMSProject(b []byte) bool {
+	for _, e := range cfbDirectoryEntries(b) {
+		name := cfbEntryName(e)
+		if len(name) < 4 || name[:3] != "   " {
+			continue
+		}
+
+		digits := true
+		for _, c := range name[3:] {
+			if c < '0' || c > '9' {
+				digits = false
+				break
+			}
+		}
+
+		if digits {
+			return true
+		}
+	}
+
+	return false
 }
 
-// applicationXShockwaveFlash reports whether the b's MIME type is
-// "application/x-shockwave-flash".
-func applicationXShockwaveFlash(b []byte) bool {
-	return len(b) > 2 &&
-		(b[0] == 0x43 ||
-			b[0] == 0x46) &&
-		b[1] == 0x57 &&
-		b[2] == 0x53
+// applicationVNDNuGetPackage reports whether the entries' MIME type is
+// "application/vnd.nuget.package".
+func applicationVNDNuGetPackage(entries []zipEntry) bool {
+	nuspec, manifest := false, false
+	for _, e := range entries {
+		name := string(e.name)
+		switch {
+		case strings.HasSuffix(name, ".nuspec") &&
+			!strings.Contains(name, "/"):
+			nuspec = true
+		case name == "_rels/.rels":
+			manifest = bytes.Contains(
+				zipEntryData(e),
+				[]byte("http://schemas.microsoft.com/packaging"+
+					"/2010/07/manifest"),
+			)
+		}
+	}
+
+	return nuspec && manifest
 }
 
-// applicationXSQLite3 reports whether the b's MIME type is
-// "application/x-sqlite3".
-func applicationXSQLite3(b []byte) bool {
-	return len(b) > 3 &&
-		b[0] == 0x53 &&
-		b[1] == 0x51 &&
-		b[2] == 0x4c &&
-		b[3] == 0x69
+// applicationVNDOASISOpenDocumentGraphics reports whether the entries' MIME
+// type is "application/vnd.oasis.opendocument.graphics".
+func applicationVNDOASISOpenDocumentGraphics(entries []zipEntry) bool {
+	return zipMimeType(entries) == "application/vnd.oasis.opendocument.graphics"
 }
 
-// applicationXTar reports whether the b's MIME type is "application/x-tar".
-func applicationXTar(b []byte) bool {
-	return len(b) > 261 &&
-		b[257] == 0x75 &&
-		b[258] == 0x73 &&
-		b[259] == 0x74 &&
-		b[260] == 0x61 &&
-		b[261] == 0x72
+// applicationVNDOASISOpenDocumentPresentation reports whether the entries' MIME
+// type is "application/vnd.oasis.opendocument.presentation".
+func applicationVNDOASISOpenDocumentPresentation(entries []zipEntry) bool {
+	return zipMimeType(entries) ==
+		"application/vnd.oasis.opendocument.presentation"
 }
 
-// applicationXUNIXArchive reports whether the b's MIME type is
-// "application/x-unix-archive".
-func applicationXUNIXArchive(b []byte) bool {
-	return len(b) > 6 &&
-		b[0] == 0x21 &&
-		b[1] == 0x3c &&
-		b[2] == 0x61 &&
-		b[3] == 0x72 &&
-		b[4] == 0x63 &&
-		b[5] == 0x68 &&
-		b[6] == 0x3e
+// applicationVNDOASISOpenDocumentSpreadsheet reports whether the entries' MIME
+// type is "application/vnd.oasis.opendocument.spreadsheet".
+func applicationVNDOASISOpenDocumentSpreadsheet(entries []zipEntry) bool {
+	return zipMimeType(entries) == "application/vnd.oasis.opendocument.spreadsheet"
 }
 
-// applicationXXZ reports whether the b's MIME type is "application/x-xz".
-func applicationXXZ(b []byte) bool {
-	return len(b) > 5 &&
-		b[0] == 0xfd &&
-		b[1] == 0x37 &&
-		b[2] == 0x7a &&
-		b[3] == 0x58 &&
-		b[4] == 0x5a &&
-		b[5] == 0x00
+// applicationVNDOASISOpenDocumentText reports whether the entries' MIME type is
+// "application/vnd.oasis.opendocument.text".
+func applicationVNDOASISOpenDocumentText(entries []zipEntry) bool {
+	return zipMimeType(entries) == "application/vnd.oasis.opendocument.text"
 }
 
-// audioAAC reports whether the b's MIME type is "audio/aac".
-func audioAAC(b []byte) bool {
-	return len(b) > 1 &&
-		(b[0] == 0xff &&
-			b[1] == 0xf1 ||
-			b[0] == 0xff &&
-				b[1] == 0xf9)
+// applicationVNDOpenXMLFormatsOfficeDocumentPresentationMLPresentation reports
+// whether the entries' MIME type is
+// "application/vnd.openxmlformats-officedocument.presentationml.presentation".
+func applicationVNDOpenXMLFormatsOfficeDocumentPresentationMLPresentation(
+	entries []zipEntry,
+) bool {
+	return ooxmlApplication(entries) == "powerpoint"
 }
 
-// audioAMR reports whether the b's MIME type is "audio/amr".
-func audioAMR(b []byte) bool {
-	return len(b) > 11 &&
-		b[0] == 0x23 &&
-		b[1] == 0x21 &&
-		b[2] == 0x41 &&
-		b[3] == 0x4d &&
-		b[4] == 0x52 &&
-		b[5] == 0x0a
+// applicationVNDOpenXMLFormatsOfficeDocumentSpreadsheeetMLSheet reports whether
+// the entries' MIME type is
+// "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet".
+func applicationVNDOpenXMLFormatsOfficeDocumentSpreadsheeetMLSheet(
+	entries []zipEntry,
+) bool {
+	return ooxmlApplication(entries) == "excel"
 }
 
-// audioM4A reports whether the b's MIME type is "audio/m4a".
-func audioM4A(b []byte) bool {
-	return len(b) > 10 &&
-		(b[4] == 0x66 &&
-			b[5] == 0x74 &&
-			b[6] == 0x79 &&
-			b[7] == 0x70 &&
-			b[8] == 0x4d &&
-			b[9] == 0x34 &&
-			b[10] == 0x41 ||
-			b[0] == 0x4d &&
-				b[1] == 0x34 &&
-				b[2] == 0x41 &&
-				b[3] == 0x20)
+// applicationVNDOpenXMLFormatsOfficeDocumentWordprocessingMLDocument reports
+// whether the entries' MIME type is
+// "application/vnd.openxmlformats-officedocument.wordprocessingml.document".
+func applicationVNDOpenXMLFormatsOfficeDocumentWordprocessingMLDocument(
+	entries []zipEntry,
+) bool {
+	return ooxmlApplication(entries) == "word"
 }
 
-// audioOgg reports whether the b's MIME type is "audio/ogg".
-func audioOgg(b []byte) bool {
-	return len(b) > 3 &&
-		b[0] == 0x4f &&
-		b[1] == 0x67 &&
-		b[2] == 0x67 &&
-		b[3] == 0x53
+// applicationVNDRAR reports whether the b's MIME type is "application/vnd.rar".
+func applicationVNDRAR(b []byte) bool {
+	return len(b) > 6 &&
+		b[0] == 0x52 &&
+		b[1] == 0x61 &&
+		b[2] == 0x72 &&
+		b[3] == 0x21 &&
+		b[4] == 0x1a &&
+		b[5] == 0x07 &&
+		(b[6] == 0x00 ||
+			len(b) > 7 &&
+				b[6] == 0x01 &&
+				b[7] == 0x00)
 }
 
-// audioXFLAC reports whether the b's MIME type is "audio/x-flac".
-func audioXFLAC(b []byte) bool {
-	return len(b) > 3 &&
-		b[0] == 0x66 &&
-		b[1] == 0x4c &&
-		b[2] == 0x61 &&
-		b[3] == 0x43
+// applicationVSIX reports whether the entries' MIME type is "application/vsix".
+func applicationVSIX(entries []zipEntry) bool {
+	return zipContains(entries, "extension.vsixmanifest")
 }
 
-// audioXWAV reports whether the b's MIME type is "audio/x-wav".
-func audioXWAV(b []byte) bool {
-	return len(b) > 11 &&
-		b[0] == 0x52 &&
-		b[1] == 0x49 &&
-		b[2] == 0x46 &&
-		b[3] == 0x46 &&
-		b[8] == 0x57 &&
-		b[9] == 0x41 &&
-		b[10] == 0x56 &&
-		b[11] == 0x45
+// applicationWasm reports whether the b's MIME type is "application/wasm".
+func applicationWasm(b []byte) bool {
+	return len(b) > 7 &&
+		b[0] == 0x00 &&
+		b[1] == 0x61 &&
+		b[2] == 0x73 &&
+		b[3] == 0x6d &&
+		b[4] == 0x01 &&
+		b[5] == 0x00 &&
+		b[6] == 0x00 &&
+		b[7] == 0x00
 }
 
-// imageJP2 reports whether the b's MIME type is "image/jp2".
-func imageJP2(b []byte) bool {
-	return len(b) > 12 &&
-		b[0] == 0x0 &&
-		b[1] == 0x0 &&
-		b[2] == 0x0 &&
-		b[3] == 0xc &&
-		b[4] == 0x6a &&
-		b[5] == 0x50 &&
-		b[6] == 0x20 &&
-		b[7] == 0x20 &&
-		b[8] == 0xd &&
-		b[9] == 0xa &&
-		b[10] == 0x87 &&
-		b[11] == 0xa &&
-		b[12] == 0x0
+// applicationX7ZCompressed reports whether the b's MIME type is
+// "application/x-7z-compressed".
+func applicationX7ZCompressed(b []byte) bool {
+	return len(b) > 5 &&
+		b[0] == 0x37 &&
+		b[1] == 0x7a &&
+		b[2] == 0xbc &&
+		b[3] == 0xaf &&
+		b[4] == 0x27 &&
+		b[5] == 0x1c
 }
 
-// imageTIFF reports whether the b's MIME type is "image/tiff".
-func imageTIFF(b []byte) bool {
-	return len(b) > 3 &&
-		(b[0] == 0x49 &&
-			b[1] == 0x49 &&
-			b[2] == 0x2a &&
-			b[3] == 0x0 ||
-			b[0] == 0x4d &&
-				b[1] == 0x4d &&
-				b[2] == 0x0 &&
-				b[3] == 0x2a)
+// applicationXACECompressed reports whether the b's MIME type is
+// "application/x-ace-compressed".
+func applicationXACECompressed(b []byte) bool {
+	return len(b) > 13 &&
+		b[7] == 0x2a &&
+		b[8] == 0x2a &&
+		b[9] == 0x41 &&
+		b[10] == 0x43 &&
+		b[11] == 0x45 &&
+		b[12] == 0x2a &&
+		b[13] == 0x2a
 }
 
-// imageVNDAdobePhotoshop reports whether the b's MIME type is
-// "image/vnd.adobe.photoshop".
-func imageVNDAdobePhotoshop(b []byte) bool {
-	return len(b) > 3 &&
-		b[0] == 0x38 &&
-		b[1] == 0x42 &&
-		b[2] == 0x50 &&
-		b[3] == 0x53
+// applicationXArchive reports whether the b's MIME type is
+// "application/x-archive".
+func applicationXArchive(b []byte) bool {
+	_, ok := arMemberNames(b)
+	return ok
 }
 
-// imageXCanonCR2 reports whether the b's MIME type is "image/x-canon-cr2".
-func imageXCanonCR2(b []byte) bool {
-	return len(b) > 9 &&
-		(b[0] == 0x49 &&
-			b[1] == 0x49 &&
-			b[2] == 0x2a &&
-			b[3] == 0x0 ||
-			b[0] == 0x4d &&
-				b[1] == 0x4d &&
-				b[2] == 0x0 &&
-				b[3] == 0x2a) &&
-		b[8] == 0x43 && b[9] == 0x52
+// applicationXBzip2 reports whether the b's MIME type is "application/x-bzip2".
+func applicationXBzip2(b []byte) bool {
+	return len(b) > 2 &&
+		b[0] == 0x42 &&
+		b[1] == 0x5a &&
+		b[2] == 0x68
 }
 
-// videoMPEG reports whether the b's MIME type is "video/mpeg".
-func videoMPEG(b []byte) bool {
+// applicationXCOFFObject reports whether the b's MIME type is
+// "application/x-coff-object".
+func applicationXCOFFObject(b []byte) bool {
+	if len(b) < 60 {
+		return false
+	}
+
+	switch binary.LittleEndian.Uint16(b[:2]) {
+	case 0x014c, 0x01c4, 0x8664, 0xaa64: // i386, ARMv7, AMD64, ARM64
+	default:
+		return false
+	}
+
+	sections := binary.LittleEndian.Uint16(b[2:4])
+	if sections == 0 ||
+		sections > 0xfeff ||
+		binary.LittleEndian.Uint16(b[16:18]) != 0 || // No optional header
+		binary.LittleEndian.Uint16(b[18:20])&0x0002 != 0 || // Not an image
+		b[20] != '.' && b[20] != '/' {
+		return false
+	}
+
+	for _, c := range b[21:28] {
+		if c != 0x00 && (c < 0x20 || c > 0x7e) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applicationXCompress reports whether the b's MIME type is
+// "application/x-compress".
+func applicationXCompress(b []byte) bool {
+	return len(b) > 1 &&
+		(b[0] == 0x1f &&
+			b[1] == 0xa0 ||
+			b[0] == 0x1f &&
+				b[1] == 0x9d)
+}
+
+// applicationXCPIO reports whether the b's MIME type is "application/x-cpio".
+func applicationXCPIO(b []byte) bool {
+	if len(b) > 1 &&
+		(b[0] == 0xc7 &&
+			b[1] == 0x71 ||
+			b[0] == 0x71 &&
+				b[1] == 0xc7) {
+		return true
+	}
+
+	if len(b) < 14 || !bytes.HasPrefix(b, []byte("07070")) {
+		return false
+	}
+
+	digits := "01234567"
+	switch b[5] {
+	case '1', '2': // New ASCII format
+		digits = "0123456789abcdefABCDEF"
+	case '7': // Old ASCII format
+	default:
+		return false
+	}
+
+	for _, c := range b[6:14] {
+		if strings.IndexByte(digits, c) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applicationXDoomWAD reports whether the b's MIME type is
+// "application/x-doom-wad".
+func applicationXDoomWAD(b []byte) bool {
+	return len(b) > 11 &&
+		(b[0] == 0x49 || b[0] == 0x50) &&
+		b[1] == 0x57 &&
+		b[2] == 0x41 &&
+		b[3] == 0x44 &&
+		int32(binary.LittleEndian.Uint32(b[4:8])) >= 0 &&
+		binary.LittleEndian.Uint32(b[8:12]) >= 12
+}
+
+// applicationXDotNetAssembly reports whether the b's MIME type is
+// "application/x-dotnet-assembly".
+func applicationXDotNetAssembly(b []byte) bool {
+	oh := peOptionalHeader(b)
+	if len(oh) < 2 {
+		return false
+	}
+
+	var dd int // Offset of the data directories
+	switch binary.LittleEndian.Uint16(oh[:2]) {
+	case 0x10b: // PE32
+		dd = 96
+	case 0x20b: // PE32+
+		dd = 112
+	default:
+		return false
+	}
+
+	const clr = 14 // Index of the CLR runtime header data directory
+	return len(oh) >= dd+8*(clr+1) &&
+		binary.LittleEndian.Uint32(oh[dd-4:dd]) > clr &&
+		binary.LittleEndian.Uint32(oh[dd+8*clr:]) != 0 &&
+		binary.LittleEndian.Uint32(oh[dd+8*clr+4:]) != 0
+}
+
+// applicationXEFIApplication reports whether the b's MIME type is
+// "application/x-efi-application".
+func applicationXEFIApplication(b []byte) bool {
+	oh := peOptionalHeader(b)
+	if len(oh) < 70 {
+		return false
+	}
+
+	switch binary.LittleEndian.Uint16(oh[68:70]) { // Subsystem
+	case 10, 11, 12, 13:
+		return true
+	}
+
+	return false
+}
+
+// applicationXExecutable reports whether the b's MIME type is
+// "application/x-executable".
+func applicationXExecutable(b []byte) bool {
+	return len(b) > 52 &&
+		b[0] == 0x7f &&
+		b[1] == 0x45 &&
+		b[2] == 0x4c &&
+		b[3] == 0x46
+}
+
+// applicationXFictionBookXML reports whether the b's MIME type is
+// "application/x-fictionbook+xml".
+func applicationXFictionBookXML(b []byte) bool {
+	name, _, ok := xmlRootElement(b)
+	return ok && name == "FictionBook"
+}
+
+// applicationXFirmware reports whether the b's MIME type is
+// "application/x-firmware".
+func applicationXFirmware(b []byte) bool {
+	return len(b) > 43 &&
+		b[40] == 0x5f &&
+		b[41] == 0x46 &&
+		b[42] == 0x56 &&
+		b[43] == 0x48
+}
+
+// applicationXFontBDF reports whether the b's MIME type is
+// "application/x-font-bdf".
+func applicationXFontBDF(b []byte) bool {
+	return bytes.HasPrefix(b, []byte("STARTFONT 2."))
+}
+
+// applicationXFontPCF reports whether the b's MIME type is
+// "application/x-font-pcf".
+func applicationXFontPCF(b []byte) bool {
 	return len(b) > 3 &&
-		b[0] == 0x0 &&
-		b[1] == 0x0 &&
-		b[2] == 0x1 &&
-		b[3] >= 0xb0 &&
-		b[3] <= 0xbf
+		b[0] == 0x01 &&
+		b[1] == 0x66 &&
+		b[2] == 0x63 &&
+		b[3] == 0x70
 }
 
-// videoQuickTime reports whether the b's MIME type is "video/quicktime".
-func videoQuickTime(b []byte) bool {
-	return len(b) > 15 &&
-		(b[0] == 0x0 &&
-			b[1] == 0x0 &&
-			b[2] == 0x0 &&
-			b[3] == 0x14 &&
-			b[4] == 0x66 &&
-			b[5] == 0x74 &&
-			b[6] == 0x79 &&
-			b[7] == 0x70 ||
-			b[4] == 0x6d &&
-				b[5] == 0x6f &&
-				b[6] == 0x6f &&
-				b[7] == 0x76 ||
-			b[4] == 0x6d &&
-				b[5] == 0x64 &&
-				b[6] == 0x61 &&
-				b[7] == 0x74 ||
-			b[12] == 0x6d &&
-				b[13] == 0x64 &&
-				b[14] == 0x61 &&
-				b[15] == 0x74)
+// applicationXFontType1 reports whether the b's MIME type is
+// "application/x-font-type1".
+func applicationXFontType1(b []byte) bool {
+	if len(b) > 5 && b[0] == 0x80 && b[1] == 0x01 { // PFB
+		b = b[6:]
+	}
+
+	return bytes.HasPrefix(b, []byte("%!PS-AdobeFont-")) ||
+		bytes.HasPrefix(b, []byte("%!FontType1-"))
 }
 
-// videoXFLV reports whether the b's MIME type is "video/x-flv".
-func videoXFLV(b []byte) bool {
+// applicationXGameCubeROM reports whether the b's MIME type is
+// "application/x-gamecube-rom".
+func applicationXGameCubeROM(b []byte) bool {
+	return len(b) > 0x1f &&
+		b[0x1c] == 0xc2 &&
+		b[0x1d] == 0x33 &&
+		b[0x1e] == 0x9f &&
+		b[0x1f] == 0x3d ||
+		wiaDiscType(b) == 1
+}
+
+// applicationXGBAROM reports whether the b's MIME type is
+// "application/x-gba-rom".
+func applicationXGBAROM(b []byte) bool {
+	if len(b) < 0xc0 ||
+		!bytes.Equal(b[0x04:0x0c], nintendoLogoPrefix) ||
+		b[0xb2] != 0x96 {
+		return false
+	}
+
+	var sum byte
+	for _, c := range b[0xa0:0xbd] {
+		sum += c
+	}
+
+	return b[0xbd] == -(sum + 0x19)
+}
+
+// applicationXGenesisROM reports whether the b's MIME type is
+// "application/x-genesis-rom".
+func applicationXGenesisROM(b []byte) bool {
+	return len(b) > 0x10f &&
+		(bytes.HasPrefix(b[0x100:], []byte("SEGA MEGA DRIVE")) ||
+			bytes.HasPrefix(b[0x100:], []byte("SEGA GENESIS")))
+}
+
+// applicationXGitObject reports whether the b's MIME type is
+// "application/x-git-object".
+func applicationXGitObject(b []byte) bool {
+	if !applicationZlib(b) {
+		return false
+	}
+
+	h := make([]byte, 32)
+	n, _ := io.ReadFull(flate.NewReader(bytes.NewReader(b[2:])), h)
+	h = h[:n]
+
+	i := bytes.IndexByte(h, ' ')
+	if i < 0 {
+		return false
+	}
+
+	switch string(h[:i]) {
+	case "blob", "commit", "tag", "tree":
+	default:
+		return false
+	}
+
+	j := bytes.IndexByte(h, 0x00)
+	if j <= i+1 {
+		return false
+	}
+
+	for _, c := range h[i+1 : j] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applicationXGitPack reports whether the b's MIME type is
+// "application/x-git-pack".
+func applicationXGitPack(b []byte) bool {
+	return len(b) > 7 &&
+		b[0] == 0x50 &&
+		b[1] == 0x41 &&
+		b[2] == 0x43 &&
+		b[3] == 0x4b &&
+		b[4] == 0x00 &&
+		b[5] == 0x00 &&
+		b[6] == 0x00 &&
+		(b[7] == 0x02 || b[7] == 0x03)
+}
+
+// applicationXGitPackIndex reports whether the b's MIME type is
+// "application/x-git-pack-index".
+func applicationXGitPackIndex(b []byte) bool {
+	return len(b) > 7 &&
+		b[0] == 0xff &&
+		b[1] == 0x74 &&
+		b[2] == 0x4f &&
+		b[3] == 0x63 &&
+		b[4] == 0x00 &&
+		b[5] == 0x00 &&
+		b[6] == 0x00 &&
+		b[7] == 0x02
+}
+
+// applicationXGoogleChromeExtension reports whether the b's MIME type is
+// "application/x-google-chrome-extension".
+func applicationXGoogleChromeExtension(b []byte) bool {
 	return len(b) > 3 &&
-		b[0] == 0x46 &&
-		b[1] == 0x4c &&
-		b[2] == 0x56 &&
-		b[3] == 0x01
+		b[0] == 0x43 &&
+		b[1] == 0x72 &&
+		b[2] == 0x32 &&
+		b[3] == 0x34
 }
 
-// videoXM4V reports whether the b's MIME type is "video/x-m4v".
-func videoXM4V(b []byte) bool {
-	return len(b) > 10 &&
-		b[4] == 0x66 &&
-		b[5] == 0x74 &&
-		b[6] == 0x79 &&
-		b[7] == 0x70 &&
-		b[8] == 0x4d &&
-		b[9] == 0x34 &&
-		b[10] == 0x56
+// applicationXISO9660Image reports whether the b's MIME type is
+// "application/x-iso9660-image". The volume descriptors start at 32768, so the
+// b must be at least that long.
+func applicationXISO9660Image(b []byte) bool {
+	return len(b) > 0x8006 &&
+		(b[0x8000] <= 0x03 ||
+			b[0x8000] == 0xff) &&
+		b[0x8001] == 0x43 &&
+		b[0x8002] == 0x44 &&
+		b[0x8003] == 0x30 &&
+		b[0x8004] == 0x30 &&
+		b[0x8005] == 0x31 &&
+		b[0x8006] == 0x01
 }
 
-// videoXMatroska reports whether the b's MIME type is "video/x-matroska".
-func videoXMatroska(b []byte) bool {
-	return (len(b) > 15 &&
-		b[0] == 0x1a &&
-		b[1] == 0x45 &&
-		b[2] == 0xdf &&
-		b[3] == 0xa3 &&
-		b[4] == 0x93 &&
-		b[5] == 0x42 &&
-		b[6] == 0x82 &&
-		b[7] == 0x88 &&
-		b[8] == 0x6d &&
-		b[9] == 0x61 &&
-		b[10] == 0x74 &&
-		b[11] == 0x72 &&
-		b[12] == 0x6f &&
-		b[13] == 0x73 &&
-		b[14] == 0x6b &&
-		b[15] == 0x61) ||
-		(len(b) > 38 &&
-			b[31] == 0x6d &&
-			b[32] == 0x61 &&
-			b[33] == 0x74 &&
-			b[34] == 0x72 &&
-			b[35] == 0x6f &&
-			b[36] == 0x73 &&
-			b[37] == 0x6b &&
-			b[38] == 0x61)
+// applicationXITunesIPA reports whether the entries' MIME type is
+// "application/x-itunes-ipa".
+func applicationXITunesIPA(entries []zipEntry) bool {
+	for _, e := range entries {
+		name := e.name
+		if !bytes.HasPrefix(name, []byte("Payload/")) {
+			continue
+		}
+
+		name = name[8:]
+		if i := bytes.IndexByte(name, '/'); i > 4 &&
+			bytes.HasSuffix(name[:i], []byte(".app")) {
+			return true
+		}
+	}
+
+	return false
 }
 
-// videoXMSWMV reports whether the b's MIME type is "video/x-ms-wmv".
-func videoXMSWMV(b []byte) bool {
-	return len(b) > 9 &&
-		b[0] == 0x30 &&
-		b[1] == 0x26 &&
-		b[2] == 0xb2 &&
-		b[3] == 0x75 &&
-		b[4] == 0x8e &&
-		b[5] == 0x66 &&
-		b[6] == 0xcf &&
-		b[7] == 0x11 &&
-		b[8] == 0xa6 &&
-		b[9] == 0xd9
+// applicationXLevelDBCurrent reports whether the b's MIME type is
+// "application/x-leveldb-current".
+func applicationXLevelDBCurrent(b []byte) bool {
+	if !bytes.HasPrefix(b, []byte("MANIFEST-")) ||
+		len(b) < 11 ||
+		b[len(b)-1] != '\n' {
+		return false
+	}
+
+	for _, c := range b[9 : len(b)-1] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
 }
 
-// videoXMSVideo reports whether the b's MIME type is "video/x-msvideo".
-func videoXMSVideo(b []byte) bool {
-	return len(b) > 10 &&
-		b[0] == 0x52 &&
-		b[1] == 0x49 &&
-		b[2] == 0x46 &&
-		b[3] == 0x46 &&
-		b[8] == 0x41 &&
-		b[9] == 0x56 &&
-		b[10] == 0x49
+// applicationXLevelDBManifest reports whether the b's MIME type is
+// "application/x-leveldb-manifest".
+func applicationXLevelDBManifest(b []byte) bool {
+	// The first log record must hold a version edit that begins with the
+	// comparator name.
+	if len(b) < 10 ||
+		b[6] != 0x01 && b[6] != 0x02 || // Full or first fragment
+		b[7] != 0x01 || // Comparator tag
+		b[8] >= 0x80 {
+		return false
+	}
+
+	return bytes.HasPrefix(b[9:], []byte("leveldb.")) ||
+		bytes.HasPrefix(b[9:], []byte("rocksdb."))
+}
+
+// applicationXLevelDBSST reports whether the b's MIME type is
+// "application/x-leveldb-sst". It can only be determined if the b is the whole
+// file, since the magic number is at the end of the footer.
+func applicationXLevelDBSST(b []byte) bool {
+	if len(b) < 48 {
+		return false
+	}
+
+	switch binary.LittleEndian.Uint64(b[len(b)-8:]) {
+	case 0xdb4775248b80fb57, // LevelDB
+		0x88e241b785f4cff7, // RocksDB block-based table
+		0x926789d0c5f17873: // RocksDB plain table
+		return true
+	}
+
+	return false
+}
+
+// applicationXLuaBytecode reports whether the b's MIME type is
+// "application/x-lua-bytecode".
+func applicationXLuaBytecode(b []byte) bool {
+	return len(b) > 4 &&
+		b[0] == 0x1b &&
+		b[1] == 0x4c &&
+		b[2] == 0x75 &&
+		b[3] == 0x61 &&
+		b[4] >= 0x50 &&
+		b[4] <= 0x54
+}
+
+// applicationXLZ4 reports whether the b's MIME type is "application/x-lz4".
+func applicationXLZ4(b []byte) bool {
+	return len(b) > 4 &&
+		b[0] == 0x04 &&
+		b[1] == 0x22 &&
+		b[2] == 0x4d &&
+		b[3] == 0x18 &&
+		b[4]>>6 == 0x01 ||
+		len(b) > 3 &&
+			b[0] == 0x02 &&
+			b[1] == 0x21 &&
+			b[2] == 0x4c &&
+			b[3] == 0x18
+}
+
+// applicationXLzip reports whether the b's MIME type is "application/x-lzip".
+func applicationXLzip(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x4c &&
+		b[1] == 0x5a &&
+		b[2] == 0x49 &&
+		b[3] == 0x50
+}
+
+// applicationXLzop reports whether the b's MIME type is "application/x-lzop".
+func applicationXLzop(b []byte) bool {
+	return len(b) > 8 &&
+		b[0] == 0x89 &&
+		b[1] == 0x4c &&
+		b[2] == 0x5a &&
+		b[3] == 0x4f &&
+		b[4] == 0x00 &&
+		b[5] == 0x0d &&
+		b[6] == 0x0a &&
+		b[7] == 0x1a &&
+		b[8] == 0x0a
+}
+
+// applicationXMachBinary reports whether the b's MIME type is
+// "application/x-mach-binary".
+func applicationXMachBinary(b []byte) bool {
+	if len(b) < 8 {
+		return false
+	}
+
+	switch binary.BigEndian.Uint32(b[:4]) {
+	case 0xfeedface, 0xfeedfacf, 0xcefaedfe, 0xcffaedfe:
+		return true
+	case 0xcafebabe, 0xcafebabf: // Universal binary
+		// Java class files share the magic number, but their major
+		// versions (45 or greater) exceed any sane architecture count.
+		n := binary.BigEndian.Uint32(b[4:8])
+		return n > 0 && n < 20
+	}
+
+	return false
+}
+
+// applicationXMobipocketEbook reports whether the b's MIME type is
+// "application/x-mobipocket-ebook".
+func applicationXMobipocketEbook(b []byte) bool {
+	return len(b) > 67 &&
+		(b[60] == 0x42 &&
+			b[61] == 0x4f &&
+			b[62] == 0x4f &&
+			b[63] == 0x4b &&
+			b[64] == 0x4d &&
+			b[65] == 0x4f &&
+			b[66] == 0x42 &&
+			b[67] == 0x49 ||
+			b[60] == 0x54 &&
+				b[61] == 0x45 &&
+				b[62] == 0x58 &&
+				b[63] == 0x74 &&
+				b[64] == 0x52 &&
+				b[65] == 0x45 &&
+				b[66] == 0x41 &&
+				b[67] == 0x64)
+}
+
+// applicationXMSDownload reports whether the b's MIME type is
+// "application/x-msdownload".
+func applicationXMSDownload(b []byte) bool {
+	return len(b) > 1 &&
+		b[0] == 0x4d &&
+		b[1] == 0x5a
+}
+
+// applicationXMSI reports whether the b's MIME type is "application/x-msi".
+func applicationXMSI(b []byte) bool {
+	entries := cfbDirectoryEntries(b)
+	return len(entries) > 0 &&
+		bytes.Equal(entries[0][0x50:0x60], []byte{
+			0x84, 0x10, 0x0c, 0x00, 0x00, 0x00, 0x00, 0x00,
+			0xc0, 0x00, 0x00, 0x00, 0x00, 0x00, 0x00, 0x46,
+		})
+}
+
+// applicationXMSLib reports whether the b's MIME type is "application/x-mslib".
+func applicationXMSLib(b []byte) bool {
+	names, _ := arMemberNames(b)
+	return len(names) > 1 && names[0] == "/" && names[1] == "/"
+}
+
+// applicationXN64ROM reports whether the b's MIME type is
+// "application/x-n64-rom".
+func applicationXN64ROM(b []byte) bool {
+	return len(b) > 3 &&
+		(b[0] == 0x80 &&
+			b[1] == 0x37 &&
+			b[2] == 0x12 &&
+			b[3] == 0x40 ||
+			b[0] == 0x37 &&
+				b[1] == 0x80 &&
+				b[2] == 0x40 &&
+				b[3] == 0x12 ||
+			b[0] == 0x40 &&
+				b[1] == 0x12 &&
+				b[2] == 0x37 &&
+				b[3] == 0x80)
+}
+
+// applicationXNDJSON reports whether the lines' MIME type is
+// "application/x-ndjson".
+func applicationXNDJSON(lines [][]byte) bool {
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) < 2 {
+		return false
+	}
+
+	for _, l := range lines {
+		l = bytes.TrimSpace(l)
+		if len(l) == 0 ||
+			l[0] != '{' && l[0] != '[' ||
+			!json.Valid(l) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// applicationXNetCDF reports whether the b's MIME type is
+// "application/x-netcdf".
+func applicationXNetCDF(b []byte) bool {
+	if len(b) > 3 &&
+		b[0] == 0x43 &&
+		b[1] == 0x44 &&
+		b[2] == 0x46 &&
+		(b[3] == 0x01 || b[3] == 0x02 || b[3] == 0x05) {
+		return true
+	}
+
+	// NetCDF-4 files are HDF5 files whose root group carries the
+	// NetCDF-specific attributes. The HDF5 superblock may follow a user
+	// block, so it is at the offset 0, 512, 1024, 2048 and so on.
+	o := 0
+	for o <= hdf5SuperblockLimit && o+8 <= len(b) {
+		if bytes.Equal(b[o:o+8], []byte("\x89HDF\r\n\x1a\n")) {
+			w := b[o:]
+			if len(w) > hdf5RootGroupWindow {
+				w = w[:hdf5RootGroupWindow]
+			}
+
+			return bytes.Contains(w, []byte("_NCProperties")) ||
+				bytes.Contains(w, []byte("_Netcdf4"))
+		}
+
+		if o == 0 {
+			o = 512
+		} else {
+			o *= 2
+		}
+	}
+
+	return false
+}
+
+// applicationXNintendoDSROM reports whether the b's MIME type is
+// "application/x-nintendo-ds-rom".
+func applicationXNintendoDSROM(b []byte) bool {
+	return len(b) > 0x15d &&
+		bytes.Equal(b[0xc0:0xc8], nintendoLogoPrefix) &&
+		b[0x15c] == 0x56 &&
+		b[0x15d] == 0xcf
+}
+
+// applicationXNintendoNESROM reports whether the b's MIME type is
+// "application/x-nintendo-nes-rom".
+func applicationXNintendoNESROM(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x4e &&
+		b[1] == 0x45 &&
+		b[2] == 0x53 &&
+		b[3] == 0x1a
+}
+
+// applicationXORC reports whether the b's MIME type is "application/x-orc".
+func applicationXORC(b []byte) bool {
+	if len(b) < 4 ||
+		b[0] != 0x4f ||
+		b[1] != 0x52 ||
+		b[2] != 0x43 {
+		return false
+	}
+
+	// The magic is followed by the binary stripe data, so anything that
+	// reads as text is just text beginning with "ORC".
+	_, text := textLines(b)
+	return !text
+}
+
+// applicationXPEMFile reports whether the b's MIME type is
+// "application/x-pem-file".
+func applicationXPEMFile(b []byte) bool {
+	return pemLabel(b) != ""
+}
+
+// applicationXPythonBytecode reports whether the b's MIME type is
+// "application/x-python-bytecode".
+func applicationXPythonBytecode(b []byte) bool {
+	if len(b) < 8 || b[2] != 0x0d || b[3] != 0x0a {
+		return false
+	}
+
+	magic := binary.LittleEndian.Uint16(b[:2])
+	switch {
+	case magic >= 62011 && magic <= 62211: // Python 2.3 to 2.7
+		return true
+	case magic >= 3000 && magic < 3392: // Python 3.0 to 3.6
+		return true
+	case magic >= 3392 && magic < 4000: // Python 3.7+ (PEP 552)
+		return binary.LittleEndian.Uint32(b[4:8]) <= 0x03
+	}
+
+	return false
+}
+
+// applicationXQEMUDisk reports whether the b's MIME type is
+// "application/x-qemu-disk".
+func applicationXQEMUDisk(b []byte) bool {
+	return len(b) > 7 &&
+		b[0] == 0x51 &&
+		b[1] == 0x46 &&
+		b[2] == 0x49 &&
+		b[3] == 0xfb &&
+		binary.BigEndian.Uint32(b[4:8]) >= 1 &&
+		binary.BigEndian.Uint32(b[4:8]) <= 3
+}
+
+// applicationXQuakePAK reports whether the b's MIME type is
+// "application/x-quake-pak".
+func applicationXQuakePAK(b []byte) bool {
+	return len(b) > 11 &&
+		b[0] == 0x50 &&
+		b[1] == 0x41 &&
+		b[2] == 0x43 &&
+		b[3] == 0x4b &&
+		binary.LittleEndian.Uint32(b[4:8]) >= 12 &&
+		binary.LittleEndian.Uint32(b[8:12])%64 == 0
+}
+
+// applicationXRPM reports whether the b's MIME type is "application/x-rpm".
+func applicationXRPM(b []byte) bool {
+	return len(b) > 96 &&
+		b[0] == 0xed &&
+		b[1] == 0xab &&
+		b[2] == 0xee &&
+		b[3] == 0xdb
+}
+
+// applicationXShockwaveFlash reports whether the b's MIME type is
+// "application/x-shockwave-flash".
+func applicationXShockwaveFlash(b []byte) bool {
+	return len(b) > 2 &&
+		(b[0] == 0x43 ||
+			b[0] == 0x46) &&
+		b[1] == 0x57 &&
+		b[2] == 0x53
+}
+
+// applicationXSNESROM reports whether the b's MIME type is
+// "application/x-snes-rom".
+func applicationXSNESROM(b []byte) bool {
+	// The internal header of a LoROM or a HiROM image, optionally preceded
+	// by a 512-byte copier header.
+	for _, o := range []int{0x7fc0, 0x81c0, 0xffc0, 0x101c0} {
+		if len(b) < o+0x20 {
+			break
+		}
+
+		h := b[o : o+0x20]
+		if h[0x15]&0xe0 != 0x20 || // Map mode
+			binary.LittleEndian.Uint16(h[0x1c:0x1e])^
+				binary.LittleEndian.Uint16(h[0x1e:0x20]) != 0xffff {
+			continue
+		}
+
+		title := true
+		for _, c := range h[:0x15] {
+			if c < 0x20 || c > 0x7e {
+				title = false
+				break
+			}
+		}
+
+		if title {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applicationXSQLite3 reports whether the b's MIME type is
+// "application/x-sqlite3".
+func applicationXSQLite3(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x53 &&
+		b[1] == 0x51 &&
+		b[2] == 0x4c &&
+		b[3] == 0x69
+}
+
+// applicationXSQLite3Journal reports whether the b's MIME type is
+// "application/x-sqlite3-journal".
+func applicationXSQLite3Journal(b []byte) bool {
+	return len(b) > 7 &&
+		b[0] == 0xd9 &&
+		b[1] == 0xd5 &&
+		b[2] == 0x05 &&
+		b[3] == 0xf9 &&
+		b[4] == 0x20 &&
+		b[5] == 0xa1 &&
+		b[6] == 0x63 &&
+		b[7] == 0xd7
+}
+
+// applicationXSQLite3WAL reports whether the b's MIME type is
+// "application/x-sqlite3-wal".
+func applicationXSQLite3WAL(b []byte) bool {
+	return len(b) > 7 &&
+		b[0] == 0x37 &&
+		b[1] == 0x7f &&
+		b[2] == 0x06 &&
+		(b[3] == 0x82 || b[3] == 0x83) &&
+		binary.BigEndian.Uint32(b[4:8]) == 3007000
+}
+
+// applicationXStaticLibrary reports whether the b's MIME type is
+// "application/x-static-library".
+func applicationXStaticLibrary(b []byte) bool {
+	names, _ := arMemberNames(b)
+	return len(names) > 0 &&
+		(names[0] == "/" ||
+			names[0] == "/SYM64/" ||
+			names[0] == "__.SYMDEF" ||
+			names[0] == "__.SYMDEF SORTED" ||
+			names[0] == "__.SYMDEF_64" ||
+			names[0] == "__.SYMDEF_64 SORTED")
+}
+
+// applicationXSubRip reports whether the lines' MIME type is
+// "application/x-subrip".
+func applicationXSubRip(lines [][]byte) bool {
+	for len(lines) > 0 && len(bytes.TrimSpace(lines[0])) == 0 {
+		lines = lines[1:]
+	}
+
+	if len(lines) < 2 || len(lines[0]) == 0 {
+		return false
+	}
+
+	for _, c := range lines[0] {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	isTime := func(b []byte) bool {
+		for i, c := range b {
+			switch i {
+			case 2, 5:
+				if c != ':' {
+					return false
+				}
+			case 8:
+				if c != ',' {
+					return false
+				}
+			default:
+				if c < '0' || c > '9' {
+					return false
+				}
+			}
+		}
+
+		return len(b) == 12
+	}
+
+	l := lines[1]
+
+	return len(l) >= 29 &&
+		isTime(l[:12]) &&
+		string(l[12:17]) == " --> " &&
+		isTime(l[17:29])
+}
+
+// applicationXTar reports whether the b's MIME type is "application/x-tar".
+func applicationXTar(b []byte) bool {
+	return len(b) > 261 &&
+		b[257] == 0x75 &&
+		b[258] == 0x73 &&
+		b[259] == 0x74 &&
+		b[260] == 0x61 &&
+		b[261] == 0x72
+}
+
+// applicationXTeXPK reports whether the b's MIME type is
+// "application/x-tex-pk".
+func applicationXTeXPK(b []byte) bool {
+	return len(b) > 1 &&
+		b[0] == 0xf7 &&
+		b[1] == 0x59
+}
+
+// applicationXTeXTFM reports whether the b's MIME type is
+// "application/x-tex-tfm".
+func applicationXTeXTFM(b []byte) bool {
+	if len(b) < 24 {
+		return false
+	}
+
+	var w [12]int
+	for i := range w {
+		w[i] = int(binary.BigEndian.Uint16(b[2*i:]))
+	}
+
+	lf, lh, bc, ec := w[0], w[1], w[2], w[3]
+	if lh < 2 ||
+		bc > ec+1 ||
+		ec > 255 ||
+		w[4] < 1 || // nw
+		w[5] < 1 || // nh
+		w[6] < 1 || // nd
+		w[7] < 1 { // ni
+		return false
+	}
+
+	n := 6 + lh + ec - bc + 1
+	for _, v := range w[4:] {
+		n += v
+	}
+
+	return lf == n
+}
+
+// applicationXVHD reports whether the b's MIME type is "application/x-vhd".
+func applicationXVHD(b []byte) bool {
+	return len(b) > 7 &&
+		b[0] == 0x63 &&
+		b[1] == 0x6f &&
+		b[2] == 0x6e &&
+		b[3] == 0x65 &&
+		b[4] == 0x63 &&
+		b[5] == 0x74 &&
+		b[6] == 0x69 &&
+		b[7] == 0x78
+}
+
+// applicationXVHDX reports whether the b's MIME type is "application/x-vhdx".
+func applicationXVHDX(b []byte) bool {
+	return len(b) > 7 &&
+		b[0] == 0x76 &&
+		b[1] == 0x68 &&
+		b[2] == 0x64 &&
+		b[3] == 0x78 &&
+		b[4] == 0x66 &&
+		b[5] == 0x69 &&
+		b[6] == 0x6c &&
+		b[7] == 0x65
+}
+
+// applicationXVirtualBoxVDI reports whether the b's MIME type is
+// "application/x-virtualbox-vdi".
+func applicationXVirtualBoxVDI(b []byte) bool {
+	return len(b) > 0x43 &&
+		b[0] == 0x3c &&
+		b[1] == 0x3c &&
+		b[2] == 0x3c &&
+		b[3] == 0x20 &&
+		b[0x40] == 0x7f &&
+		b[0x41] == 0x10 &&
+		b[0x42] == 0xda &&
+		b[0x43] == 0xbe
+}
+
+// applicationXVMDK reports whether the b's MIME type is "application/x-vmdk".
+func applicationXVMDK(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x4b &&
+		b[1] == 0x44 &&
+		b[2] == 0x4d &&
+		b[3] == 0x56
+}
+
+// applicationXWiiROM reports whether the b's MIME type is
+// "application/x-wii-rom".
+func applicationXWiiROM(b []byte) bool {
+	return len(b) > 0x1b &&
+		b[0x18] == 0x5d &&
+		b[0x19] == 0x1c &&
+		b[0x1a] == 0x9e &&
+		b[0x1b] == 0xa3 ||
+		len(b) > 3 &&
+			b[0] == 0x57 &&
+			b[1] == 0x42 &&
+			b[2] == 0x46 &&
+			b[3] == 0x53 ||
+		wiaDiscType(b) == 2
+}
+
+// applicationXX509CACert reports whether the b's MIME type is
+// "application/x-x509-ca-cert".
+func applicationXX509CACert(b []byte) bool {
+	return x509Kind(b) == "certificate"
+}
+
+// applicationXXAR reports whether the b's MIME type is "application/x-xar".
+func applicationXXAR(b []byte) bool {
+	return len(b) > 7 &&
+		b[0] == 0x78 &&
+		b[1] == 0x61 &&
+		b[2] == 0x72 &&
+		b[3] == 0x21 &&
+		binary.BigEndian.Uint16(b[4:6]) >= 28 &&
+		b[6] == 0x00 &&
+		b[7] == 0x01
+}
+
+// applicationXXPInstall reports whether the entries' MIME type is
+// "application/x-xpinstall".
+func applicationXXPInstall(entries []zipEntry) bool {
+	return zipContains(entries, "install.rdf") ||
+		zipContains(entries, "manifest.json") &&
+			zipContains(
+				entries,
+				"META-INF/cose.manifest",
+				"META-INF/cose.sig",
+				"META-INF/mozilla.rsa",
+				"META-INF/mozilla.sf",
+			)
+}
+
+// applicationXXZ reports whether the b's MIME type is "application/x-xz".
+func applicationXXZ(b []byte) bool {
+	return len(b) > 5 &&
+		b[0] == 0xfd &&
+		b[1] == 0x37 &&
+		b[2] == 0x7a &&
+		b[3] == 0x58 &&
+		b[4] == 0x5a &&
+		b[5] == 0x00
+}
+
+// applicationXYAML reports whether the lines' MIME type is
+// "application/x-yaml".
+func applicationXYAML(lines [][]byte) bool {
+	if len(lines) == 0 {
+		return false
+	}
+
+	if bytes.HasPrefix(lines[0], []byte("%YAML ")) {
+		return true
+	}
+
+	// Bare "key: value" lines are common in prose, so the lines must also have
+	// a document start marker or keys whose values are nested blocks.
+	started, nested, block, keys := false, false, false, 0
+	for _, l := range lines {
+		if t := bytes.TrimLeft(l, " "); len(t) == 0 || t[0] == '#' {
+			continue
+		}
+
+		switch {
+		case bytes.Equal(l, []byte("---")),
+			bytes.HasPrefix(l, []byte("--- ")):
+			started = started || keys == 0
+			block = false
+		case bytes.Equal(l, []byte("...")):
+			block = false
+		case l[0] == ' ',
+			l[0] == '-' && (len(l) == 1 || l[1] == ' '):
+			if !block {
+				return false
+			}
+
+			nested = true
+		default:
+			v, ok := yamlKey(l)
+			if !ok {
+				return false
+			}
+
+			// Values ending like sentences are most likely prose.
+			if !started && len(v) > 0 && bytes.IndexByte(
+				[]byte(".!?"),
+				v[len(v)-1],
+			) >= 0 {
+				return false
+			}
+
+			block = len(v) == 0 ||
+				len(v) <= 3 && (v[0] == '|' || v[0] == '>')
+			keys++
+		}
+	}
+
+	return keys > 0 && (started || nested)
+}
+
+// applicationXZipCompressedFB2 reports whether the entries' MIME type is
+// "application/x-zip-compressed-fb2".
+func applicationXZipCompressedFB2(entries []zipEntry) bool {
+	for _, e := range entries {
+		if !strings.HasSuffix(strings.ToLower(string(e.name)), ".fb2") {
+			return false
+		}
+	}
+
+	return len(entries) > 0
+}
+
+// applicationXHTMLXML reports whether the b's MIME type is
+// "application/xhtml+xml".
+func applicationXHTMLXML(b []byte) bool {
+	name, ns, ok := xmlRootElement(b)
+	return ok && name == "html" && ns == "http://www.w3.org/1999/xhtml"
+}
+
+// applicationXML reports whether the b's MIME type is "application/xml".
+//
+// Unlike the http.DetectContentType, which reports "text/xml; charset=utf-8",
+// it follows RFC 7303 in preferring "application/xml" for XML documents, so
+// that the charset is left to the XML declaration. The "text/xml" is only
+// reported for the data that merely starts like XML.
+func applicationXML(b []byte) bool {
+	_, _, ok := xmlRootElement(b)
+	return ok
+}
+
+// applicationZlib reports whether the b's MIME type is "application/zlib".
+func applicationZlib(b []byte) bool {
+	if len(b) < 3 ||
+		b[0] != 0x78 ||
+		b[1]&0x20 != 0 || // FDICT
+		(uint16(b[0])<<8|uint16(b[1]))%31 != 0 ||
+		b[2]>>1&0x03 == 0x03 { // BTYPE
+		return false
+	}
+
+	// The header alone is too weak, so make sure that the beginning of the
+	// compressed data can be inflated. The b may be truncated.
+	_, err := ioutil.ReadAll(io.LimitReader(
+		flate.NewReader(bytes.NewReader(b[2:])),
+		512,
+	))
+	return err == nil || err == io.ErrUnexpectedEOF
+}
+
+// audioAAC reports whether the b's MIME type is "audio/aac".
+func audioAAC(b []byte) bool {
+	return len(b) > 1 &&
+		(b[0] == 0xff &&
+			b[1] == 0xf1 ||
+			b[0] == 0xff &&
+				b[1] == 0xf9)
+}
+
+// audioAC3 reports whether the b's MIME type is "audio/ac3".
+func audioAC3(b []byte) bool {
+	eac3, ok := ac3SyncFrame(b)
+	return ok && !eac3
+}
+
+// audioAIFF reports whether the b's MIME type is "audio/aiff".
+func audioAIFF(b []byte) bool {
+	return len(b) > 11 &&
+		b[0] == 0x46 &&
+		b[1] == 0x4f &&
+		b[2] == 0x52 &&
+		b[3] == 0x4d &&
+		b[8] == 0x41 &&
+		b[9] == 0x49 &&
+		b[10] == 0x46 &&
+		(b[11] == 0x46 ||
+			b[11] == 0x43)
+}
+
+// audioAMR reports whether the b's MIME type is "audio/amr".
+func audioAMR(b []byte) bool {
+	return len(b) > 11 &&
+		b[0] == 0x23 &&
+		b[1] == 0x21 &&
+		b[2] == 0x41 &&
+		b[3] == 0x4d &&
+		b[4] == 0x52 &&
+		b[5] == 0x0a
+}
+
+// audioAMRWB reports whether the b's MIME type is "audio/amr-wb".
+func audioAMRWB(b []byte) bool {
+	return len(b) > 8 &&
+		b[0] == 0x23 &&
+		b[1] == 0x21 &&
+		b[2] == 0x41 &&
+		b[3] == 0x4d &&
+		b[4] == 0x52 &&
+		b[5] == 0x2d &&
+		b[6] == 0x57 &&
+		b[7] == 0x42 &&
+		b[8] == 0x0a
+}
+
+// audioBasic reports whether the b's MIME type is "audio/basic".
+func audioBasic(b []byte) bool {
+	return len(b) > 23 &&
+		b[0] == 0x2e &&
+		b[1] == 0x73 &&
+		b[2] == 0x6e &&
+		b[3] == 0x64 &&
+		binary.BigEndian.Uint32(b[4:8]) >= 24 &&
+		binary.BigEndian.Uint32(b[12:16]) >= 1 &&
+		binary.BigEndian.Uint32(b[12:16]) <= 27 &&
+		binary.BigEndian.Uint32(b[16:20]) > 0 &&
+		binary.BigEndian.Uint32(b[20:24]) > 0
+}
+
+// audioEAC3 reports whether the b's MIME type is "audio/eac3".
+func audioEAC3(b []byte) bool {
+	eac3, ok := ac3SyncFrame(b)
+	return ok && eac3
+}
+
+// audioM4A reports whether the b's MIME type is "audio/m4a".
+func audioM4A(b []byte) bool {
+	return len(b) > 10 &&
+		(b[4] == 0x66 &&
+			b[5] == 0x74 &&
+			b[6] == 0x79 &&
+			b[7] == 0x70 &&
+			b[8] == 0x4d &&
+			b[9] == 0x34 &&
+			b[10] == 0x41 ||
+			b[0] == 0x4d &&
+				b[1] == 0x34 &&
+				b[2] == 0x41 &&
+				b[3] == 0x20)
+}
+
+// audioMPEG reports whether the b's MIME type is "audio/mpeg".
+func audioMPEG(b []byte) bool {
+	id3, offset := false, 0
+	if len(b) > 9 &&
+		b[0] == 0x49 &&
+		b[1] == 0x44 &&
+		b[2] == 0x33 &&
+		b[3] < 0xff &&
+		b[4] < 0xff &&
+		(b[6]|b[7]|b[8]|b[9])&0x80 == 0 {
+		id3 = true
+		offset = 10 + (int(b[6])<<21 |
+			int(b[7])<<14 |
+			int(b[8])<<7 |
+			int(b[9]))
+		if b[5]&0x10 != 0 { // Footer present
+			offset += 10
+		}
+	}
+
+	if offset+4 > len(b) {
+		return id3
+	}
+
+	n := mpegFrameLength(b[offset:])
+	if n == 0 {
+		return false
+	}
+
+	if offset+n+4 > len(b) {
+		return id3
+	}
+
+	return mpegFrameLength(b[offset+n:]) > 0
+}
+
+// audioOgg reports whether the b's MIME type is "audio/ogg".
+func audioOgg(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x4f &&
+		b[1] == 0x67 &&
+		b[2] == 0x67 &&
+		b[3] == 0x53
+}
+
+// audioOpus reports whether the b's MIME type is "audio/opus".
+func audioOpus(b []byte) bool {
+	return bytes.HasPrefix(oggFirstPacket(b), []byte("OpusHead"))
+}
+
+// audioVNDDTS reports whether the b's MIME type is "audio/vnd.dts".
+func audioVNDDTS(b []byte) bool {
+	return len(b) > 5 &&
+		(b[0] == 0x7f &&
+			b[1] == 0xfe &&
+			b[2] == 0x80 &&
+			b[3] == 0x01 ||
+			b[0] == 0xfe &&
+				b[1] == 0x7f &&
+				b[2] == 0x01 &&
+				b[3] == 0x80 ||
+			b[0] == 0x1f &&
+				b[1] == 0xff &&
+				b[2] == 0xe8 &&
+				b[3] == 0x00 &&
+				b[4] == 0x07 &&
+				b[5]&0xf0 == 0xf0 ||
+			b[0] == 0xff &&
+				b[1] == 0x1f &&
+				b[2] == 0x00 &&
+				b[3] == 0xe8 &&
+				b[4]&0xf0 == 0xf0 &&
+				b[5] == 0x07)
+}
+
+// audioWebM reports whether the b's MIME type is "audio/webm".
+func audioWebM(b []byte) bool {
+	docType, audioOnly := matroskaInfo(b)
+	return docType == "webm" && audioOnly
+}
+
+// audioXCAF reports whether the b's MIME type is "audio/x-caf".
+func audioXCAF(b []byte) bool {
+	return len(b) > 7 &&
+		b[0] == 0x63 &&
+		b[1] == 0x61 &&
+		b[2] == 0x66 &&
+		b[3] == 0x66 &&
+		b[4] == 0x00 &&
+		b[5] == 0x01 &&
+		b[6] == 0x00 &&
+		b[7] == 0x00
+}
+
+// audioXFLAC reports whether the b's MIME type is "audio/x-flac".
+func audioXFLAC(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x66 &&
+		b[1] == 0x4c &&
+		b[2] == 0x61 &&
+		b[3] == 0x43
+}
+
+// audioXIT reports whether the b's MIME type is "audio/x-it".
+func audioXIT(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x49 &&
+		b[1] == 0x4d &&
+		b[2] == 0x50 &&
+		b[3] == 0x4d
+}
+
+// audioXMatroska reports whether the b's MIME type is "audio/x-matroska".
+func audioXMatroska(b []byte) bool {
+	docType, audioOnly := matroskaInfo(b)
+	return docType == "matroska" && audioOnly
+}
+
+// audioXMod reports whether the b's MIME type is "audio/x-mod".
+func audioXMod(b []byte) bool {
+	if len(b) < 1084 {
+		return false
+	}
+
+	switch tag := b[1080:1084]; string(tag) {
+	case "M.K.", "M!K!", "FLT4", "FLT8":
+		return true
+	default:
+		isDigit := func(c byte) bool { return c >= '0' && c <= '9' }
+		return isDigit(tag[0]) &&
+			tag[1] == 'C' &&
+			tag[2] == 'H' &&
+			tag[3] == 'N' ||
+			isDigit(tag[0]) &&
+				isDigit(tag[1]) &&
+				tag[2] == 'C' &&
+				tag[3] == 'H'
+	}
+}
+
+// audioXMPEGURL reports whether the lines' MIME type is "audio/x-mpegurl".
+func audioXMPEGURL(lines [][]byte) bool {
+	return m3u(lines)
+}
+
+// audioXMSWMA reports whether the b's MIME type is "audio/x-ms-wma".
+func audioXMSWMA(b []byte) bool {
+	_, audio, video := asfStreamTypes(b)
+	return audio && !video
+}
+
+// audioXMusepack reports whether the b's MIME type is "audio/x-musepack".
+func audioXMusepack(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x4d &&
+		b[1] == 0x50 &&
+		(b[2] == 0x43 &&
+			b[3] == 0x4b ||
+			b[2] == 0x2b &&
+				b[3]&0x0f == 0x07)
+}
+
+// audioXS3M reports whether the b's MIME type is "audio/x-s3m".
+func audioXS3M(b []byte) bool {
+	return len(b) > 47 &&
+		b[44] == 0x53 &&
+		b[45] == 0x43 &&
+		b[46] == 0x52 &&
+		b[47] == 0x4d
+}
+
+// audioXSpeex reports whether the b's MIME type is "audio/x-speex". Speex
+// streams encapsulated in Ogg are reported as "audio/ogg" by the audioOgg.
+func audioXSpeex(b []byte) bool {
+	return bytes.HasPrefix(b, []byte("Speex   "))
+}
+
+// audioXTTA reports whether the b's MIME type is "audio/x-tta".
+func audioXTTA(b []byte) bool {
+	return len(b) > 5 &&
+		b[0] == 0x54 &&
+		b[1] == 0x54 &&
+		b[2] == 0x41 &&
+		b[3] == 0x31 &&
+		(b[4] == 0x01 ||
+			b[4] == 0x02) &&
+		b[5] == 0x00
+}
+
+// audioXWAV reports whether the b's MIME type is "audio/x-wav".
+func audioXWAV(b []byte) bool {
+	return len(b) > 11 &&
+		b[0] == 0x52 &&
+		b[1] == 0x49 &&
+		b[2] == 0x46 &&
+		b[3] == 0x46 &&
+		b[8] == 0x57 &&
+		b[9] == 0x41 &&
+		b[10] == 0x56 &&
+		b[11] == 0x45
+}
+
+// audioXWavPack reports whether the b's MIME type is "audio/x-wavpack".
+func audioXWavPack(b []byte) bool {
+	return len(b) > 9 &&
+		b[0] == 0x77 &&
+		b[1] == 0x76 &&
+		b[2] == 0x70 &&
+		b[3] == 0x6b &&
+		b[9] == 0x04
+}
+
+// audioXXM reports whether the b's MIME type is "audio/x-xm".
+func audioXXM(b []byte) bool {
+	return bytes.HasPrefix(b, []byte("Extended Module: "))
+}
+
+// fontCollection reports whether the b's MIME type is "font/collection".
+func fontCollection(b []byte) bool {
+	if len(b) < 16 ||
+		b[0] != 0x74 ||
+		b[1] != 0x74 ||
+		b[2] != 0x63 ||
+		b[3] != 0x66 ||
+		b[4] != 0x00 ||
+		(b[5] != 0x01 && b[5] != 0x02) ||
+		b[6] != 0x00 ||
+		b[7] != 0x00 {
+		return false
+	}
+
+	n := uint64(binary.BigEndian.Uint32(b[8:12])) // Number of fonts
+	return n > 0 &&
+		n <= 0xffff &&
+		uint64(binary.BigEndian.Uint32(b[12:16])) >= 12+4*n
+}
+
+// imageBPG reports whether the b's MIME type is "image/bpg".
+func imageBPG(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x42 &&
+		b[1] == 0x50 &&
+		b[2] == 0x47 &&
+		b[3] == 0xfb
+}
+
+// imageEMF reports whether the b's MIME type is "image/emf".
+func imageEMF(b []byte) bool {
+	return len(b) > 43 &&
+		b[0] == 0x01 &&
+		b[1] == 0x00 &&
+		b[2] == 0x00 &&
+		b[3] == 0x00 &&
+		b[40] == 0x20 &&
+		b[41] == 0x45 &&
+		b[42] == 0x4d &&
+		b[43] == 0x46
+}
+
+// imageJP2 reports whether the b's MIME type is "image/jp2".
+func imageJP2(b []byte) bool {
+	return len(b) > 12 &&
+		b[0] == 0x0 &&
+		b[1] == 0x0 &&
+		b[2] == 0x0 &&
+		b[3] == 0xc &&
+		b[4] == 0x6a &&
+		b[5] == 0x50 &&
+		b[6] == 0x20 &&
+		b[7] == 0x20 &&
+		b[8] == 0xd &&
+		b[9] == 0xa &&
+		b[10] == 0x87 &&
+		b[11] == 0xa &&
+		b[12] == 0x0
+}
+
+// imageQOI reports whether the b's MIME type is "image/qoi".
+func imageQOI(b []byte) bool {
+	return len(b) > 13 &&
+		b[0] == 0x71 &&
+		b[1] == 0x6f &&
+		b[2] == 0x69 &&
+		b[3] == 0x66 &&
+		binary.BigEndian.Uint32(b[4:8]) > 0 &&
+		binary.BigEndian.Uint32(b[8:12]) > 0 &&
+		(b[12] == 0x03 ||
+			b[12] == 0x04) &&
+		(b[13] == 0x00 ||
+			b[13] == 0x01)
+}
+
+// imageTIFF reports whether the b's MIME type is "image/tiff".
+func imageTIFF(b []byte) bool {
+	return len(b) > 3 &&
+		(b[0] == 0x49 &&
+			b[1] == 0x49 &&
+			b[2] == 0x2a &&
+			b[3] == 0x0 ||
+			b[0] == 0x4d &&
+				b[1] == 0x4d &&
+				b[2] == 0x0 &&
+				b[3] == 0x2a)
+}
+
+// imageVNDAdobePhotoshop reports whether the b's MIME type is
+// "image/vnd.adobe.photoshop".
+func imageVNDAdobePhotoshop(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x38 &&
+		b[1] == 0x42 &&
+		b[2] == 0x50 &&
+		b[3] == 0x53
+}
+
+// imageWMF reports whether the b's MIME type is "image/wmf".
+func imageWMF(b []byte) bool {
+	return len(b) > 5 &&
+		(b[0] == 0xd7 &&
+			b[1] == 0xcd &&
+			b[2] == 0xc6 &&
+			b[3] == 0x9a ||
+			(b[0] == 0x01 ||
+				b[0] == 0x02) &&
+				b[1] == 0x00 &&
+				b[2] == 0x09 &&
+				b[3] == 0x00 &&
+				b[4] == 0x00 &&
+				(b[5] == 0x01 ||
+					b[5] == 0x03))
+}
+
+// imageXAdobeDNG reports whether the b's MIME type is "image/x-adobe-dng".
+func imageXAdobeDNG(b []byte) bool {
+	_, ok := tiffTag(b, 0xc612) // DNGVersion
+	return ok
+}
+
+// imageXCanonCR2 reports whether the b's MIME type is "image/x-canon-cr2".
+func imageXCanonCR2(b []byte) bool {
+	return len(b) > 9 &&
+		(b[0] == 0x49 &&
+			b[1] == 0x49 &&
+			b[2] == 0x2a &&
+			b[3] == 0x0 ||
+			b[0] == 0x4d &&
+				b[1] == 0x4d &&
+				b[2] == 0x0 &&
+				b[3] == 0x2a) &&
+		b[8] == 0x43 && b[9] == 0x52
+}
+
+// imageXCanonCR3 reports whether the b's MIME type is "image/x-canon-cr3".
+func imageXCanonCR3(b []byte) bool {
+	brands := bmffBrands(b)
+	return len(brands) > 0 && string(brands[0]) == "crx "
+}
+
+// imageXFujiRAF reports whether the b's MIME type is "image/x-fuji-raf".
+func imageXFujiRAF(b []byte) bool {
+	return len(b) > 15 && string(b[:16]) == "FUJIFILMCCD-RAW "
+}
+
+// imageXNikonNEF reports whether the b's MIME type is "image/x-nikon-nef".
+func imageXNikonNEF(b []byte) bool {
+	return tiffRAW(b, "NIKON")
+}
+
+// imageXOlympusORF reports whether the b's MIME type is "image/x-olympus-orf".
+func imageXOlympusORF(b []byte) bool {
+	return len(b) > 3 &&
+		(b[0] == 0x49 &&
+			b[1] == 0x49 &&
+			b[2] == 0x52 &&
+			(b[3] == 0x4f ||
+				b[3] == 0x53) ||
+			b[0] == 0x4d &&
+				b[1] == 0x4d &&
+				b[2] == 0x4f &&
+				b[3] == 0x52)
+}
+
+// imageXPanasonicRW2 reports whether the b's MIME type is
+// "image/x-panasonic-rw2".
+func imageXPanasonicRW2(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x49 &&
+		b[1] == 0x49 &&
+		b[2] == 0x55 &&
+		b[3] == 0x00
+}
+
+// imageXPCX reports whether the b's MIME type is "image/x-pcx".
+func imageXPCX(b []byte) bool {
+	return len(b) > 127 &&
+		b[0] == 0x0a &&
+		(b[1] == 0x00 ||
+			b[1] == 0x02 ||
+			b[1] == 0x03 ||
+			b[1] == 0x04 ||
+			b[1] == 0x05) &&
+		b[2] == 0x01 &&
+		(b[3] == 0x01 ||
+			b[3] == 0x02 ||
+			b[3] == 0x04 ||
+			b[3] == 0x08) &&
+		b[64] == 0x00
+}
+
+// imageXPentaxPEF reports whether the b's MIME type is "image/x-pentax-pef".
+func imageXPentaxPEF(b []byte) bool {
+	return tiffRAW(b, "PENTAX")
+}
+
+// imageXSonyARW reports whether the b's MIME type is "image/x-sony-arw".
+func imageXSonyARW(b []byte) bool {
+	return tiffRAW(b, "SONY")
+}
+
+// imageXXBitmap reports whether the b's MIME type is "image/x-xbitmap".
+func imageXXBitmap(b []byte) bool {
+	if !bytes.HasPrefix(b, []byte("#define ")) {
+		return false
+	}
+
+	b = b[8:]
+	i := bytes.IndexAny(b, " \t")
+
+	return i > 0 && bytes.HasSuffix(b[:i], []byte("_width"))
+}
+
+// imageXXPixmap reports whether the b's MIME type is "image/x-xpixmap".
+func imageXXPixmap(b []byte) bool {
+	return bytes.HasPrefix(b, []byte("/* XPM */")) ||
+		bytes.HasPrefix(b, []byte("! XPM2"))
+}
+
+// messageRFC822 reports whether the lines' MIME type is "message/rfc822".
+func messageRFC822(lines [][]byte) bool {
+	return mailHeaders(lines) > 1
+}
+
+// textCalendar reports whether the b's MIME type is "text/calendar".
+func textCalendar(b []byte) bool {
+	b = utf16ToUTF8(b)
+	if !textHasPrefixFold(b, "BEGIN:VCALENDAR") {
+		return false
+	}
+
+	lines, ok := textLines(b)
+	return ok &&
+		len(lines) > 0 &&
+		bytes.EqualFold(
+			bytes.TrimSpace(lines[0]),
+			[]byte("BEGIN:VCALENDAR"),
+		)
+}
+
+// textCSV reports whether the lines' MIME type is "text/csv".
+func textCSV(lines [][]byte) bool {
+	d := textDelimiter(lines)
+	return d == ',' || d == ';'
+}
+
+// textMarkdown reports whether the lines' MIME type is "text/markdown".
+func textMarkdown(lines [][]byte) bool {
+	if bytes.HasPrefix(lines[0], []byte("#!")) {
+		return false
+	}
+
+	var headings, lists, fences, links int
+	for i, l := range lines {
+		t := bytes.TrimLeft(l, " ")
+		switch {
+		case bytes.HasPrefix(t, []byte("```")),
+			bytes.HasPrefix(t, []byte("~~~")):
+			// Unlike the underlines of reStructuredText titles,
+			// fences usually follow a blank line.
+			if i == 0 || len(bytes.TrimSpace(lines[i-1])) == 0 {
+				fences++
+			}
+		case len(t) > 1 && t[0] == '#':
+			j := bytes.IndexByte(t, ' ')
+			if j <= 0 || j > 6 || len(bytes.Trim(t[:j], "#")) > 0 {
+				break
+			}
+
+			text := bytes.TrimSpace(t[j:])
+			if k := bytes.IndexAny(text, " \t("); k >= 0 {
+				text = text[:k]
+			}
+
+			// Skip the C preprocessor directives such as "#  define".
+			switch string(text) {
+			case "",
+				"define",
+				"elif",
+				"else",
+				"endif",
+				"error",
+				"if",
+				"ifdef",
+				"ifndef",
+				"include",
+				"line",
+				"pragma",
+				"undef",
+				"warning":
+				continue
+			}
+
+			// Unlike the comments of scripts, headings are usually
+			// followed by a blank line.
+			if i+1 == len(lines) ||
+				len(bytes.TrimSpace(lines[i+1])) == 0 {
+				headings++
+			}
+		case len(t) > 1 && (t[0] == '-' || t[0] == '*' || t[0] == '+') &&
+			t[1] == ' ':
+			lists++
+		default:
+			n := 0
+			for n < len(t) && t[n] >= '0' && t[n] <= '9' {
+				n++
+			}
+
+			if n > 0 && n+1 < len(t) &&
+				(t[n] == '.' || t[n] == ')') &&
+				t[n+1] == ' ' {
+				lists++
+			}
+		}
+
+		for p := l; ; {
+			j := bytes.Index(p, []byte("]("))
+			if j < 0 {
+				break
+			}
+
+			text, dest := p[:j], p[j+2:]
+			p = dest
+			if bytes.IndexByte(dest, ')') <= 0 {
+				continue
+			}
+
+			k := bytes.LastIndexByte(text, '[')
+			if k < 0 {
+				continue
+			}
+
+			// The link text must not be an index expression such as
+			// "a[i](x)".
+			if k > 0 {
+				c := text[k-1]
+				if c >= 'A' && c <= 'Z' ||
+					c >= 'a' && c <= 'z' ||
+					c >= '0' && c <= '9' ||
+					c == '_' {
+					continue
+				}
+			}
+
+			links++
+		}
+	}
+
+	// A heading alone is too weak, as is any other construct without a
+	// heading.
+	return headings > 0 && (lists > 0 || fences > 0 || links > 0)
+}
+
+// textTabSeparatedValues reports whether the lines' MIME type is
+// "text/tab-separated-values".
+func textTabSeparatedValues(lines [][]byte) bool {
+	return textDelimiter(lines) == '\t'
+}
+
+// textVCard reports whether the b's MIME type is "text/vcard".
+func textVCard(b []byte) bool {
+	b = utf16ToUTF8(b)
+	if !textHasPrefixFold(b, "BEGIN:VCARD") {
+		return false
+	}
+
+	lines, ok := textLines(b)
+	if !ok ||
+		len(lines) == 0 ||
+		!bytes.EqualFold(bytes.TrimSpace(lines[0]), []byte("BEGIN:VCARD")) {
+		return false
+	}
+
+	for _, l := range lines[1:] {
+		if len(l) > 8 && bytes.EqualFold(l[:8], []byte("VERSION:")) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// textVTT reports whether the lines' MIME type is "text/vtt".
+func textVTT(lines [][]byte) bool {
+	return len(lines) > 0 &&
+		bytes.HasPrefix(lines[0], []byte("WEBVTT")) &&
+		(len(lines[0]) == 6 ||
+			lines[0][6] == ' ' ||
+			lines[0][6] == '\t')
+}
+
+// videoH264 reports whether the b's MIME type is "video/h264".
+func videoH264(b []byte) bool {
+	units := annexBNALUnits(b)
+	if len(units) > 1 && len(units[0]) > 0 && units[0][0]&0x1f == 0x09 {
+		units = units[1:] // Access unit delimiter
+	}
+
+	if len(units) == 0 ||
+		len(units[0]) < 2 ||
+		units[0][0]&0x9f != 0x07 || // Sequence parameter set
+		units[0][0]&0x60 == 0 {
+		return false
+	}
+
+	switch units[0][1] { // profile_idc
+	case 44, 66, 77, 83, 86, 88, 100, 110, 118, 122, 128, 134, 135, 138,
+		139, 144, 244:
+		return true
+	}
+
+	return false
+}
+
+// videoH265 reports whether the b's MIME type is "video/h265".
+func videoH265(b []byte) bool {
+	units := annexBNALUnits(b)
+	if len(units) > 1 &&
+		len(units[0]) > 1 &&
+		units[0][0] == 0x46 &&
+		units[0][1] == 0x01 {
+		units = units[1:] // Access unit delimiter
+	}
+
+	return len(units) > 0 &&
+		len(units[0]) > 1 &&
+		units[0][0] == 0x40 && // Video parameter set
+		units[0][1] == 0x01
+}
+
+// videoMP2T reports whether the b's MIME type is "video/mp2t".
+func videoMP2T(b []byte) bool {
+	for _, p := range [...]struct{ offset, size int }{
+		{0, 188}, // TS
+		{4, 192}, // M2TS
+	} {
+		n, i := 0, p.offset
+		for ; i < len(b) && b[i] == 0x47; i += p.size {
+			n++
+		}
+
+		// A sync byte every packet throughout the b, which must span
+		// at least three packets since any byte may be 0x47 by chance.
+		if n > 2 && i >= len(b) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// videoMP4 reports whether the b's MIME type is "video/mp4".
+func videoMP4(b []byte) bool {
+	brands := bmffBrands(b)
+	if len(brands) == 0 {
+		return false
+	}
+
+	switch string(brands[0]) {
+	case "avc1", "dash", "iso2", "iso3", "iso4", "iso5", "iso6", "isom",
+		"mmp4", "mp41", "mp42":
+		return true
+	}
+
+	return false
+}
+
+// videoMPEG reports whether the b's MIME type is "video/mpeg".
+func videoMPEG(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x0 &&
+		b[1] == 0x0 &&
+		b[2] == 0x1 &&
+		b[3] >= 0xb0 &&
+		b[3] <= 0xbf &&
+		(b[3] != 0xba || // Pack header of program streams
+			len(b) > 4 &&
+				(b[4]&0xc4 == 0x44 || // MPEG-2
+					b[4]&0xf1 == 0x21)) // MPEG-1
+}
+
+// videoQuickTime reports whether the b's MIME type is "video/quicktime".
+func videoQuickTime(b []byte) bool {
+	return len(b) > 15 &&
+		(b[0] == 0x0 &&
+			b[1] == 0x0 &&
+			b[2] == 0x0 &&
+			b[3] == 0x14 &&
+			b[4] == 0x66 &&
+			b[5] == 0x74 &&
+			b[6] == 0x79 &&
+			b[7] == 0x70 ||
+			b[4] == 0x6d &&
+				b[5] == 0x6f &&
+				b[6] == 0x6f &&
+				b[7] == 0x76 ||
+			b[4] == 0x6d &&
+				b[5] == 0x64 &&
+				b[6] == 0x61 &&
+				b[7] == 0x74 ||
+			b[12] == 0x6d &&
+				b[13] == 0x64 &&
+				b[14] == 0x61 &&
+				b[15] == 0x74)
+}
+
+// videoVNDRNRealMedia reports whether the b's MIME type is
+// "video/vnd.rn-realmedia".
+func videoVNDRNRealMedia(b []byte) bool {
+	return len(b) > 9 &&
+		b[0] == 0x2e &&
+		b[1] == 0x52 &&
+		b[2] == 0x4d &&
+		b[3] == 0x46 &&
+		binary.BigEndian.Uint32(b[4:8]) >= 0x12 &&
+		b[8] == 0x00 &&
+		(b[9] == 0x00 ||
+			b[9] == 0x01)
+}
+
+// videoXFLV reports whether the b's MIME type is "video/x-flv".
+func videoXFLV(b []byte) bool {
+	return len(b) > 3 &&
+		b[0] == 0x46 &&
+		b[1] == 0x4c &&
+		b[2] == 0x56 &&
+		b[3] == 0x01
+}
+
+// videoXIVF reports whether the b's MIME type is "video/x-ivf".
+func videoXIVF(b []byte) bool {
+	switch string(ivfFourCC(b)) {
+	case "", "AV01", "VP80", "VP90":
+		return false
+	}
+
+	return true
+}
+
+// videoXIVFAV1 reports whether the b's MIME type is
+// "video/x-ivf; codecs=av01".
+func videoXIVFAV1(b []byte) bool {
+	return string(ivfFourCC(b)) == "AV01"
+}
+
+// videoXIVFVP9 reports whether the b's MIME type is
+// "video/x-ivf; codecs=vp09".
+func videoXIVFVP9(b []byte) bool {
+	return string(ivfFourCC(b)) == "VP90"
+}
+
+// videoXIVFVP8 reports whether the b's MIME type is "video/x-ivf; codecs=vp8".
+func videoXIVFVP8(b []byte) bool {
+	return string(ivfFourCC(b)) == "VP80"
+}
+
+// videoXM4V reports whether the b's MIME type is "video/x-m4v".
+func videoXM4V(b []byte) bool {
+	return len(b) > 10 &&
+		b[4] == 0x66 &&
+		b[5] == 0x74 &&
+		b[6] == 0x79 &&
+		b[7] == 0x70 &&
+		b[8] == 0x4d &&
+		b[9] == 0x34 &&
+		b[10] == 0x56
+}
+
+// videoXMatroska reports whether the b's MIME type is "video/x-matroska".
+func videoXMatroska(b []byte) bool {
+	return (len(b) > 15 &&
+		b[0] == 0x1a &&
+		b[1] == 0x45 &&
+		b[2] == 0xdf &&
+		b[3] == 0xa3 &&
+		b[4] == 0x93 &&
+		b[5] == 0x42 &&
+		b[6] == 0x82 &&
+		b[7] == 0x88 &&
+		b[8] == 0x6d &&
+		b[9] == 0x61 &&
+		b[10] == 0x74 &&
+		b[11] == 0x72 &&
+		b[12] == 0x6f &&
+		b[13] == 0x73 &&
+		b[14] == 0x6b &&
+		b[15] == 0x61) ||
+		(len(b) > 38 &&
+			b[31] == 0x6d &&
+			b[32] == 0x61 &&
+			b[33] == 0x74 &&
+			b[34] == 0x72 &&
+			b[35] == 0x6f &&
+			b[36] == 0x73 &&
+			b[37] == 0x6b &&
+			b[38] == 0x61)
+}
+
+// videoXMSASF reports whether the b's MIME type is "video/x-ms-asf".
+func videoXMSASF(b []byte) bool {
+	asf, audio, video := asfStreamTypes(b)
+	return asf && !audio && !video
+}
+
+// videoXMSWMV reports whether the b's MIME type is "video/x-ms-wmv".
+func videoXMSWMV(b []byte) bool {
+	_, _, video := asfStreamTypes(b)
+	return video
+}
+
+// videoXMSVideo reports whether the b's MIME type is "video/x-msvideo".
+func videoXMSVideo(b []byte) bool {
+	return len(b) > 10 &&
+		b[0] == 0x52 &&
+		b[1] == 0x49 &&
+		b[2] == 0x46 &&
+		b[3] == 0x46 &&
+		b[8] == 0x41 &&
+		b[9] == 0x56 &&
+		b[10] == 0x49
+}
+
+// videoXYUV4MPEG reports whether the b's MIME type is "video/x-yuv4mpeg".
+func videoXYUV4MPEG(b []byte) bool {
+	return bytes.HasPrefix(b, []byte("YUV4MPEG2 "))
+}
+
+// ac3SyncFrame reports whether the b begins with a valid AC-3 or E-AC-3 sync
+// frame, and if so, whether it is an E-AC-3 one. When the b is long enough, the
+// sync frame must be followed by another one.
+func ac3SyncFrame(b []byte) (eac3, ok bool) {
+	if len(b) < 6 || b[0] != 0x0b || b[1] != 0x77 {
+		return false, false
+	}
+
+	fscod := b[4] >> 6
+	bsid := b[5] >> 3
+
+	var n int
+	switch {
+	case bsid <= 10:
+		frmsizecod := int(b[4] & 0x3f)
+		if fscod == 3 || frmsizecod >= 38 {
+			return false, false
+		}
+
+		bitrates := [...]int{
+			32, 40, 48, 56, 64, 80, 96, 112, 128, 160,
+			192, 224, 256, 320, 384, 448, 512, 576, 640,
+		}
+
+		bitrate := bitrates[frmsizecod/2]
+		switch fscod {
+		case 0: // 48 kHz
+			n = 4 * bitrate
+		case 1: // 44.1 kHz
+			n = 2 * (bitrate*320/147 + frmsizecod&1)
+		case 2: // 32 kHz
+			n = 6 * bitrate
+		}
+	case bsid <= 16:
+		if fscod == 3 && b[4]>>4&0x03 == 3 {
+			return false, false
+		}
+
+		eac3 = true
+		n = 2 * (int(b[2]&0x07)<<8 | int(b[3]) + 1)
+	default:
+		return false, false
+	}
+
+	if n+2 <= len(b) && (b[n] != 0x0b || b[n+1] != 0x77) {
+		return false, false
+	}
+
+	return eac3, true
+}
+
+// annexBNALUnits returns the NAL units of the Annex B byte stream b. It returns
+// nil if the b does not begin with a start code.
+func annexBNALUnits(b []byte) [][]byte {
+	startCode := []byte{0x00, 0x00, 0x01}
+	if !bytes.HasPrefix(b, startCode) &&
+		!bytes.HasPrefix(b, append([]byte{0x00}, startCode...)) {
+		return nil
+	}
+
+	var units [][]byte
+	for {
+		i := bytes.Index(b, startCode)
+		if i < 0 {
+			return units
+		}
+
+		b = b[i+3:]
+		j := bytes.Index(b, startCode)
+		if j < 0 {
+			return append(units, b)
+		}
+
+		units = append(units, bytes.TrimRight(b[:j], "\x00"))
+		b = b[j:]
+	}
+}
+
+// arMemberNames returns the names of the members found in the ar archive b. It
+// reports false if the b is not an ar archive.
+func arMemberNames(b []byte) ([]string, bool) {
+	if !bytes.HasPrefix(b, []byte("!<arch>\n")) {
+		return nil, false
+	}
+
+	var names []string
+	for o := uint64(8); o+60 <= uint64(len(b)); {
+		h := b[o : o+60]
+		if h[58] != '`' || h[59] != '\n' {
+			break
+		}
+
+		var size uint64
+		for _, c := range bytes.TrimRight(h[48:58], " ") {
+			if c < '0' || c > '9' {
+				return names, true
+			}
+
+			size = size*10 + uint64(c-'0')
+		}
+
+		name := strings.TrimRight(string(h[:16]), " ")
+		if strings.HasPrefix(name, "#1/") { // BSD long name
+			n := uint64(0)
+			for _, c := range name[3:] {
+				if c < '0' || c > '9' {
+					return names, true
+				}
+
+				n = n*10 + uint64(c-'0')
+			}
+
+			if n > size || o+60+n > uint64(len(b)) {
+				break
+			}
+
+			name = strings.TrimRight(string(b[o+60:o+60+n]), "\x00")
+		} else if name != "/" && name != "//" && name != "/SYM64/" {
+			name = strings.TrimSuffix(name, "/") // GNU name terminator
+		}
+
+		names = append(names, name)
+		o += 60 + size + size%2
+	}
+
+	return names, true
+}
+
+// asfStreamTypes reports whether the b is an ASF, and if so, whether it has
+// audio streams and video streams according to the Stream Properties Objects
+// found in its Header Object.
+func asfStreamTypes(b []byte) (asf, audio, video bool) {
+	header := []byte{
+		0x30, 0x26, 0xb2, 0x75, 0x8e, 0x66, 0xcf, 0x11,
+		0xa6, 0xd9, 0x00, 0xaa, 0x00, 0x62, 0xce, 0x6c,
+	}
+
+	streamProperties := []byte{
+		0x91, 0x07, 0xdc, 0xb7, 0xb7, 0xa9, 0xcf, 0x11,
+		0x8e, 0xe6, 0x00, 0xc0, 0x0c, 0x20, 0x53, 0x65,
+	}
+
+	audioMedia := []byte{
+		0x40, 0x9e, 0x69, 0xf8, 0x4d, 0x5b, 0xcf, 0x11,
+		0xa8, 0xfd, 0x00, 0x80, 0x5f, 0x5c, 0x44, 0x2b,
+	}
+
+	videoMedia := []byte{
+		0xc0, 0xef, 0x19, 0xbc, 0x4d, 0x5b, 0xcf, 0x11,
+		0xa8, 0xfd, 0x00, 0x80, 0x5f, 0x5c, 0x44, 0x2b,
+	}
+
+	if len(b) < 30 || !bytes.Equal(b[:16], header) {
+		return false, false, false
+	}
+
+	end := uint64(len(b))
+	if size := binary.LittleEndian.Uint64(b[16:24]); size < end {
+		end = size
+	}
+
+	for o := uint64(30); o+24 <= end; {
+		size := binary.LittleEndian.Uint64(b[o+16 : o+24])
+		if size < 24 {
+			break
+		}
+
+		if o+40 <= end && bytes.Equal(b[o:o+16], streamProperties) {
+			switch t := b[o+24 : o+40]; {
+			case bytes.Equal(t, audioMedia):
+				audio = true
+			case bytes.Equal(t, videoMedia):
+				video = true
+			}
+		}
+
+		if size > end-o {
+			break
+		}
+
+		o += size
+	}
+
+	return true, audio, video
+}
+
+// bmffBrands returns the major brand followed by the compatible brands of the
+// "ftyp" box at the beginning of the ISO BMFF b.
+func bmffBrands(b []byte) [][]byte {
+	if len(b) < 16 || string(b[4:8]) != "ftyp" {
+		return nil
+	}
+
+	size := int(binary.BigEndian.Uint32(b))
+	if size < 16 {
+		return nil
+	}
+
+	if size > len(b) {
+		size = len(b)
+	}
+
+	brands := [][]byte{b[8:12]}
+	for i := 16; i+4 <= size; i += 4 {
+		brands = append(brands, b[i:i+4])
+	}
+
+	return brands
+}
+
+// tiffRAW reports whether the b is a camera RAW image built on top of TIFF
+// whose "Make" tag starts with the maker.
+func tiffRAW(b []byte, maker string) bool {
+	v, ok := tiffTag(b, 0x010f) // Make
+	return ok && strings.HasPrefix(string(v), maker)
+}
+
+// tiffTag returns the value of the tag in the first IFD of the TIFF b. It
+// reports false if the b is not a TIFF or the tag cannot be found.
+func tiffTag(b []byte, tag uint16) ([]byte, bool) {
+	if len(b) < 8 {
+		return nil, false
+	}
+
+	var bo binary.ByteOrder
+	switch {
+	case b[0] == 0x49 && b[1] == 0x49:
+		bo = binary.LittleEndian
+	case b[0] == 0x4d && b[1] == 0x4d:
+		bo = binary.BigEndian
+	default:
+		return nil, false
+	}
+
+	if bo.Uint16(b[2:4]) != 0x2a {
+		return nil, false
+	}
+
+	ifd := uint64(bo.Uint32(b[4:8]))
+	if ifd < 8 || ifd+2 > uint64(len(b)) {
+		return nil, false
+	}
+
+	n := uint64(bo.Uint16(b[ifd:]))
+	for i := uint64(0); i < n; i++ {
+		e := ifd + 2 + i*12
+		if e+12 > uint64(len(b)) {
+			break
+		}
+
+		if bo.Uint16(b[e:]) != tag {
+			continue
+		}
+
+		var typeSize uint64
+		switch bo.Uint16(b[e+2:]) {
+		case 1, 2, 6, 7: // BYTE, ASCII, SBYTE, UNDEFINED
+			typeSize = 1
+		case 3, 8: // SHORT, SSHORT
+			typeSize = 2
+		case 4, 9, 11: // LONG, SLONG, FLOAT
+			typeSize = 4
+		case 5, 10, 12: // RATIONAL, SRATIONAL, DOUBLE
+			typeSize = 8
+		default:
+			return nil, false
+		}
+
+		size := uint64(bo.Uint32(b[e+4:])) * typeSize
+		if size <= 4 {
+			return b[e+8 : e+8+size], true
+		}
+
+		offset := uint64(bo.Uint32(b[e+8:]))
+		if offset+size > uint64(len(b)) {
+			return nil, false
+		}
+
+		return b[offset : offset+size], true
+	}
+
+	return nil, false
+}
+
+// utf16ToUTF8 returns the b converted to UTF-8 if it begins with a UTF-16 BOM,
+// considering at most the first 1024 bytes of the b. Otherwise, it returns the
+// b as is.
+func utf16ToUTF8(b []byte) []byte {
+	var order binary.ByteOrder
+	switch {
+	case bytes.HasPrefix(b, []byte{0xfe, 0xff}):
+		order = binary.BigEndian
+	case bytes.HasPrefix(b, []byte{0xff, 0xfe}):
+		order = binary.LittleEndian
+	default:
+		return b
+	}
+
+	if len(b) > 1024 {
+		b = b[:1024]
+	}
+
+	u := make([]uint16, 0, len(b)/2)
+	for i := 2; i+1 < len(b); i += 2 {
+		u = append(u, order.Uint16(b[i:i+2]))
+	}
+
+	return []byte(string(utf16.Decode(u)))
+}
+
+// wiaDiscType returns the disc type (1 for GameCube, 2 for Wii) stored in the
+// WIA or RVZ disc image b, or 0 if the b is not such a disc image.
+func wiaDiscType(b []byte) uint32 {
+	if len(b) < 0x4c ||
+		string(b[:4]) != "WIA\x01" && string(b[:4]) != "RVZ\x01" {
+		return 0
+	}
+
+	return binary.BigEndian.Uint32(b[0x48:0x4c])
+}
+
+// x509Kind returns "certificate" or "crl" if the b is a DER-encoded X.509
+// certificate or CRL. Otherwise, it returns "".
+func x509Kind(b []byte) string {
+	tag, outer, _, ok := derElement(b)
+	if !ok || tag != 0x30 {
+		return ""
+	}
+
+	tag, tbs, _, ok := derElement(outer)
+	if !ok || tag != 0x30 {
+		return ""
+	}
+
+	// The tags of the leading elements of the to-be-signed part
+	var tags []byte
+	for len(tags) < 5 {
+		tag, content, n, ok := derElement(tbs)
+		if !ok {
+			break
+		}
+
+		if tag == 0x30 &&
+			bytes.IndexByte(tags, 0x30) < 0 &&
+			(len(content) == 0 || content[0] != 0x06) {
+			return "" // The signature AlgorithmIdentifier
+		}
+
+		tags = append(tags, tag)
+		if n >= uint64(len(tbs)) {
+			break
+		}
+
+		tbs = tbs[n:]
+	}
+
+	t := string(bytes.TrimPrefix(tags, []byte{0xa0})) // Version
+	switch {
+	case strings.HasPrefix(t, "\x02\x30\x30\x30"): // Serial number
+		return "certificate"
+	case strings.HasPrefix(strings.TrimPrefix(t, "\x02"), "\x30\x30\x17"),
+		strings.HasPrefix(strings.TrimPrefix(t, "\x02"), "\x30\x30\x18"):
+		return "crl"
+	}
+
+	return ""
+}
+
+// xmlRootElement returns the local name and the namespace of the root element
+// of the XML b. It reports false if the b does not look like an XML document,
+// that is, if it has neither an XML declaration nor a namespaced root element.
+func xmlRootElement(b []byte) (name, namespace string, ok bool) {
+	b = bytes.TrimPrefix(b, []byte{0xef, 0xbb, 0xbf})
+
+	declared := false
+	for {
+		b = bytes.TrimLeft(b, " \t\r\n")
+		if bytes.HasPrefix(b, []byte("<?")) {
+			if bytes.HasPrefix(b, []byte("<?xml")) &&
+				len(b) > 5 &&
+				bytes.IndexByte([]byte(" \t\r\n"), b[5]) >= 0 {
+				declared = true
+			}
+
+			i := bytes.Index(b, []byte("?>"))
+			if i < 0 {
+				return "", "", false
+			}
+
+			b = b[i+2:]
+		} else if bytes.HasPrefix(b, []byte("<!--")) {
+			i := bytes.Index(b[4:], []byte("-->"))
+			if i < 0 {
+				return "", "", false
+			}
+
+			b = b[4+i+3:]
+		} else if bytes.HasPrefix(b, []byte("<!")) {
+			i, quote, subset := 2, byte(0), false
+			for ; i < len(b); i++ {
+				c := b[i]
+				if quote != 0 {
+					if c == quote {
+						quote = 0
+					}
+				} else if c == '"' || c == '\'' {
+					quote = c
+				} else if c == '[' {
+					subset = true
+				} else if c == ']' {
+					subset = false
+				} else if c == '>' && !subset {
+					break
+				}
+			}
+
+			if i == len(b) {
+				return "", "", false
+			}
+
+			b = b[i+1:]
+		} else {
+			break
+		}
+	}
+
+	if len(b) < 2 || b[0] != '<' {
+		return "", "", false
+	}
+
+	b = b[1:]
+	i := bytes.IndexAny(b, " \t\r\n/>")
+	if i <= 0 {
+		return "", "", false
+	}
+
+	if c := b[0]; c != '_' && c != ':' &&
+		(c < 'A' || c > 'Z') &&
+		(c < 'a' || c > 'z') &&
+		c < 0x80 {
+		return "", "", false
+	}
+
+	name = string(b[:i])
+	nsAttr := "xmlns"
+	if j := strings.IndexByte(name, ':'); j >= 0 {
+		nsAttr += ":" + name[:j]
+		name = name[j+1:]
+	}
+
+	b = b[i:]
+	for {
+		b = bytes.TrimLeft(b, " \t\r\n")
+		if len(b) == 0 {
+			return "", "", false
+		}
+
+		if b[0] == '>' || b[0] == '/' {
+			break
+		}
+
+		i := bytes.IndexByte(b, '=')
+		if i <= 0 {
+			return "", "", false
+		}
+
+		attr := string(bytes.TrimRight(b[:i], " \t\r\n"))
+		b = bytes.TrimLeft(b[i+1:], " \t\r\n")
+		if len(b) == 0 || b[0] != '"' && b[0] != '\'' {
+			return "", "", false
+		}
+
+		j := bytes.IndexByte(b[1:], b[0])
+		if j < 0 {
+			return "", "", false
+		}
+
+		if attr == nsAttr {
+			namespace = string(b[1 : j+1])
+		}
+
+		b = b[j+2:]
+	}
+
+	if !declared && namespace == "" {
+		return "", "", false
+	}
+
+	return name, namespace, true
+}
+
+// oggFirstPacket returns the beginning of the first packet in the first page of
+// the Ogg b.
+func oggFirstPacket(b []byte) []byte {
+	if len(b) < 27 ||
+		b[0] != 0x4f ||
+		b[1] != 0x67 ||
+		b[2] != 0x67 ||
+		b[3] != 0x53 {
+		return nil
+	}
+
+	start := 27 + int(b[26])
+	if start > len(b) {
+		return nil
+	}
+
+	return b[start:]
+}
+
+// peOptionalHeader returns the optional header of the PE (Portable Executable)
+// b. It returns nil if the b is not a PE.
+func peOptionalHeader(b []byte) []byte {
+	if len(b) < 0x40 || b[0] != 0x4d || b[1] != 0x5a {
+		return nil
+	}
+
+	pe := uint64(binary.LittleEndian.Uint32(b[0x3c:0x40]))
+	if pe+24 > uint64(len(b)) || string(b[pe:pe+4]) != "PE\x00\x00" {
+		return nil
+	}
+
+	end := pe + 24 + uint64(binary.LittleEndian.Uint16(b[pe+20:pe+22]))
+	if end > uint64(len(b)) {
+		end = uint64(len(b))
+	}
+
+	return b[pe+24 : end]
+}
+
+// pemLabel returns the label of the PEM (Privacy-Enhanced Mail) encapsulation
+// boundary that begins the text b, or "" if there is none. The boundary may
+// only be preceded by blank lines and the explanatory text in the form printed
+// by OpenSSL, so that documents and source code merely containing PEM data are
+// not mistaken for PEM files.
+func pemLabel(b []byte) string {
+	p := b
+	if len(p) > 512 {
+		p = p[:512]
+	}
+
+	if !bytes.Contains(p, []byte("-----BEGIN ")) {
+		return ""
+	}
+
+	lines, ok := textLines(b)
+	if !ok || mailHeaders(lines) > 1 {
+		return ""
+	}
+
+	explanatory := false
+	for _, l := range lines {
+		l = bytes.TrimRight(l, " \t")
+		switch {
+		case bytes.HasPrefix(l, []byte("-----BEGIN ")):
+			if bytes.HasSuffix(l, []byte("-----")) && len(l) > 16 {
+				return string(l[11 : len(l)-5])
+			}
+
+			return ""
+		case len(l) == 0:
+			continue
+		case l[0] == ' ', l[0] == '\t':
+			if !explanatory {
+				return ""
+			}
+
+			continue
+		case bytes.Equal(l, []byte("Bag Attributes")),
+			bytes.Equal(l, []byte("Key Attributes")):
+			explanatory = true
+			continue
+		}
+
+		// Such as "subject=CN = foo" and "Certificate:".
+		i := bytes.IndexAny(l, ":=")
+		if i <= 0 || l[i-1] == ' ' {
+			return ""
+		}
+
+		for _, c := range l[:i] {
+			if (c < 'A' || c > 'Z') &&
+				(c < 'a' || c > 'z') &&
+				(c < '0' || c > '9') &&
+				c != ' ' &&
+				c != '-' &&
+				c != '.' &&
+				c != '_' {
+				return ""
+			}
+		}
+
+		if v := bytes.TrimLeft(l[i+1:], " "); len(v) > 0 &&
+			(v[0] == '"' || v[0] == '\'' || v[0] == '`') {
+			return ""
+		}
+
+		explanatory = true
+	}
+
+	return ""
+}
+
+// pgpPacket returns the tag and the body of the OpenPGP packet at the beginning
+// of the b. The body may be truncated if the b ends early. It reports false if
+// the b does not begin with a valid packet header.
+func pgpPacket(b []byte) (tag byte, body []byte, ok bool) {
+	if len(b) < 2 || b[0]&0x80 == 0 {
+		return 0, nil, false
+	}
+
+	var hl int
+	if b[0]&0x40 == 0 { // Old format
+		tag = b[0] >> 2 & 0x0f
+		switch b[0] & 0x03 {
+		case 0:
+			hl = 2
+		case 1:
+			hl = 3
+		case 2:
+			hl = 5
+		default:
+			hl = 1 // Indeterminate length
+		}
+	} else { // New format
+		tag = b[0] & 0x3f
+		switch {
+		case b[1] < 192:
+			hl = 2
+		case b[1] < 224:
+			hl = 3
+		case b[1] == 255:
+			hl = 6
+		default:
+			hl = 2 // Partial body length
+		}
+	}
+
+	if tag == 0 || len(b) < hl {
+		return 0, nil, false
+	}
+
+	return tag, b[hl:], true
+}
+
+// pgpPublicKeyAlgorithm reports whether the id is a known OpenPGP public-key
+// algorithm ID.
+func pgpPublicKeyAlgorithm(id byte) bool {
+	switch id {
+	case 1, 2, 3, 16, 17, 18, 19, 20, 21, 22, 25, 26, 27, 28:
+		return true
+	}
+
+	return false
+}
+
+// rarFileNames returns the names of the file entries found in the RAR b.
+func rarFileNames(b []byte) [][]byte {
+	if len(b) < 7 || string(b[:6]) != "Rar!\x1a\x07" {
+		return nil
+	}
+
+	var names [][]byte
+	if b[6] == 0x00 { // RAR 1.5 to 4.x
+		for o := uint64(7); o+7 <= uint64(len(b)); {
+			headType := b[o+2]
+			headFlags := binary.LittleEndian.Uint16(b[o+3:])
+			next := o + uint64(binary.LittleEndian.Uint16(b[o+5:]))
+			if next < o+7 {
+				break
+			}
+
+			if headFlags&0x8000 != 0 || headType == 0x74 {
+				if o+11 > uint64(len(b)) {
+					break
+				}
+
+				next += uint64(binary.LittleEndian.Uint32(b[o+7:]))
+			}
+
+			if headType == 0x74 && o+32 <= uint64(len(b)) {
+				start := o + 32
+				if headFlags&0x100 != 0 {
+					start += 8
+				}
+
+				end := start + uint64(binary.LittleEndian.Uint16(b[o+26:]))
+				if end > uint64(len(b)) {
+					break
+				}
+
+				names = append(names, b[start:end])
+			}
+
+			o = next
+		}
+
+		return names
+	}
+
+	if len(b) < 8 || b[6] != 0x01 || b[7] != 0x00 { // RAR 5.0
+		return nil
+	}
+
+	vint := func(b []byte, o *uint64) uint64 {
+		var v uint64
+		for i := uint(0); *o < uint64(len(b)) && i < 64; i += 7 {
+			c := b[*o]
+			*o++
+			v |= uint64(c&0x7f) << i
+			if c&0x80 == 0 {
+				return v
+			}
+		}
+
+		*o = ^uint64(0)
+
+		return 0
+	}
+
+	for o := uint64(8); o+4 < uint64(len(b)); {
+		p := o + 4
+		headerSize := vint(b, &p)
+		if p > uint64(len(b)) {
+			break
+		}
+
+		next := p + headerSize
+		headerType := vint(b, &p)
+		headerFlags := vint(b, &p)
+		if headerFlags&0x01 != 0 {
+			vint(b, &p) // Extra area size
+		}
+
+		if headerFlags&0x02 != 0 {
+			next += vint(b, &p) // Data size
+		}
+
+		if headerType == 2 { // File header
+			fileFlags := vint(b, &p)
+			vint(b, &p) // Unpacked size
+			vint(b, &p) // Attributes
+			if fileFlags&0x02 != 0 {
+				p += 4 // mtime
+			}
+
+			if fileFlags&0x04 != 0 {
+				p += 4 // Data CRC32
+			}
+
+			vint(b, &p) // Compression information
+			vint(b, &p) // Host OS
+			n := vint(b, &p)
+			if p > uint64(len(b)) || n > uint64(len(b))-p {
+				break
+			}
+
+			names = append(names, b[p:p+n])
+		}
+
+		if next <= o {
+			break
+		}
+
+		o = next
+	}
+
+	return names
+}
+
+// textDelimiter returns the field delimiter of the tabular text with the
+// lines. It returns zero if there are fewer than three lines, or no delimiter
+// splits every line into the same number of fields, or most of the fields look
+// like prose rather than data.
+func textDelimiter(lines [][]byte) byte {
+	for len(lines) > 0 && len(lines[len(lines)-1]) == 0 {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) < 3 {
+		return 0
+	}
+
+	delimiter, fields := byte(0), 1
+	for _, d := range []byte{',', '\t', ';'} {
+		n, total, prose := 0, 0, 0
+		for _, l := range lines {
+			// Lines indented with the d are not records.
+			if len(l) == 0 || l[0] == d {
+				n = 0
+				break
+			}
+
+			m, p := textFields(l, d)
+			if n == 0 {
+				n = m
+			}
+
+			if m != n || n <= fields {
+				n = 0
+				break
+			}
+
+			total += m
+			prose += p
+		}
+
+		if n > fields && 2*prose <= total {
+			delimiter, fields = d, n
+		}
+	}
+
+	return delimiter
+}
+
+// textFields returns the number of fields in the l delimited by the d, and how
+// many of them look like prose rather than data. The d inside double-quoted
+// fields is not treated as a delimiter.
+func textFields(l []byte, d byte) (n, prose int) {
+	start, quoted := 0, false
+	for i := 0; i <= len(l); i++ {
+		if i < len(l) {
+			if l[i] == '"' {
+				quoted = !quoted
+			}
+
+			if l[i] != d || quoted {
+				continue
+			}
+		}
+
+		n++
+
+		f := bytes.Trim(l[start:i], " \"")
+		start = i + 1
+		if len(f) == 0 {
+			continue
+		}
+
+		// Words separated by spaces, sentences and punctuation such
+		// as the "//" of comments.
+		data := false
+		for _, c := range f {
+			if c == ' ' {
+				data = false
+				break
+			}
+
+			if c >= 'A' && c <= 'Z' ||
+				c >= 'a' && c <= 'z' ||
+				c >= '0' && c <= '9' {
+				data = true
+			}
+		}
+
+		switch f[len(f)-1] {
+		case '.', '!', '?', ':':
+			data = false
+		}
+
+		if !data {
+			prose++
+		}
+	}
+
+	return n, prose
+}
+
+// textHasPrefixFold reports whether the text b begins with the prefix, ignoring
+// the case, the UTF-8 BOM and the leading spaces.
+func textHasPrefixFold(b []byte, prefix string) bool {
+	b = bytes.TrimLeft(bytes.TrimPrefix(b, []byte{0xef, 0xbb, 0xbf}), " \t")
+	return len(b) >= len(prefix) &&
+		bytes.EqualFold(b[:len(prefix)], []byte(prefix))
+}
+
+// textLines returns the lines of the text b, considering at most the first 512
+// bytes of the b. The last line is dropped if it may have been cut off. It
+// reports false if the b contains binary data.
+func textLines(b []byte) ([][]byte, bool) {
+	b = bytes.TrimPrefix(b, []byte{0xef, 0xbb, 0xbf})
+	if len(b) > 512 {
+		b = b[:512]
+		if i := bytes.LastIndexByte(b, '\n'); i >= 0 {
+			b = b[:i]
+		}
+	}
+
+	for _, c := range b {
+		if c <= 0x08 ||
+			c == 0x0b ||
+			c >= 0x0e && c <= 0x1a ||
+			c >= 0x1c && c <= 0x1f {
+			return nil, false
+		}
+	}
+
+	lines := bytes.Split(b, []byte{'\n'})
+	for i, l := range lines {
+		lines[i] = bytes.TrimSuffix(l, []byte{'\r'})
+	}
+
+	return lines, true
+}
+
+// yamlKey reports whether the l is a line of a top-level YAML mapping entry,
+// and returns the entry's value if so.
+func yamlKey(l []byte) ([]byte, bool) {
+	i := 0
+	if l[0] == '"' || l[0] == '\'' {
+		j := bytes.IndexByte(l[1:], l[0])
+		if j < 0 {
+			return nil, false
+		}
+
+		i = j + 2
+	} else {
+		for i < len(l) && (l[i] >= 'A' && l[i] <= 'Z' ||
+			l[i] >= 'a' && l[i] <= 'z' ||
+			l[i] >= '0' && l[i] <= '9' ||
+			l[i] == '_' ||
+			l[i] == '-' ||
+			l[i] == '.') {
+			i++
+		}
+
+		if i == 0 {
+			return nil, false
+		}
+	}
+
+	if i >= len(l) || l[i] != ':' || i+1 < len(l) && l[i+1] != ' ' {
+		return nil, false
+	}
+
+	return bytes.TrimSpace(l[i+1:]), true
+}
+
+// cfbOfficeApplication returns the name of the Office application ("excel",
+// "powerpoint" or "word") that created the CFB (Compound File Binary) b, or ""
+// if it cannot be determined.
+func cfbOfficeApplication(b []byte) string {
+	for _, e := range cfbDirectoryEntries(b) {
+		switch cfbEntryName(e) {
+		case "Workbook", "Book":
+			return "excel"
+		case "PowerPoint Document":
+			return "powerpoint"
+		case "WordDocument":
+			return "word"
+		}
+	}
+
+	return ""
+}
+
+// cfbDirectoryEntries returns the 128-byte directory entries of the CFB
+// (Compound File Binary) b, following the directory sector chain as far as the
+// b allows.
+func cfbDirectoryEntries(b []byte) [][]byte {
+	if len(b) < 512 ||
+		b[0] != 0xd0 ||
+		b[1] != 0xcf ||
+		b[2] != 0x11 ||
+		b[3] != 0xe0 ||
+		b[4] != 0xa1 ||
+		b[5] != 0xb1 ||
+		b[6] != 0x1a ||
+		b[7] != 0xe1 {
+		return nil
+	}
+
+	var sectorSize uint64
+	switch binary.LittleEndian.Uint16(b[30:32]) {
+	case 9:
+		sectorSize = 512
+	case 12:
+		sectorSize = 4096
+	default:
+		return nil
+	}
+
+	sector := func(id uint32) []byte {
+		offset := (uint64(id) + 1) * sectorSize
+		if offset+sectorSize > uint64(len(b)) {
+			return nil
+		}
+
+		return b[offset : offset+sectorSize]
+	}
+
+	var entries [][]byte
+	id := binary.LittleEndian.Uint32(b[48:52])
+	for i := 0; id <= 0xfffffffa && i < 1024; i++ {
+		s := sector(id)
+		if s == nil {
+			break
+		}
+
+		for o := 0; o+128 <= len(s); o += 128 {
+			entries = append(entries, s[o:o+128])
+		}
+
+		// Look up the next sector in the FAT sectors listed in the
+		// header's DIFAT.
+		n := uint32(sectorSize / 4)
+		if id/n >= 109 {
+			break
+		}
+
+		fat := sector(binary.LittleEndian.Uint32(b[76+4*(id/n):]))
+		if fat == nil {
+			break
+		}
+
+		id = binary.LittleEndian.Uint32(fat[4*(id%n):])
+	}
+
+	return entries
+}
+
+// cfbEntryName returns the name of the CFB directory entry e.
+func cfbEntryName(e []byte) string {
+	n := int(binary.LittleEndian.Uint16(e[0x40:0x42]))
+	if n > 64 {
+		n = 64
+	}
+
+	var name []rune
+	for i := 0; i+1 < n; i += 2 {
+		c := rune(binary.LittleEndian.Uint16(e[i : i+2]))
+		if c == 0 {
+			break
+		}
+
+		name = append(name, c)
+	}
+
+	return string(name)
+}
+
+// comicBook reports whether the names of the archive entries are predominantly
+// image file names.
+func comicBook(names [][]byte) bool {
+	images, others := 0, 0
+	for _, name := range names {
+		if len(name) == 0 ||
+			name[len(name)-1] == '/' ||
+			string(name) == "ComicInfo.xml" {
+			continue
+		}
+
+		switch strings.ToLower(path.Ext(string(name))) {
+		case ".bmp", ".gif", ".jpeg", ".jpg", ".png", ".tif", ".tiff",
+			".webp":
+			images++
+		default:
+			others++
+		}
+	}
+
+	return images > others
+}
+
+// derElement returns the tag and the content of the ASN.1 DER element at the
+// beginning of the b, and the length of the entire element. The content may be
+// truncated if the b ends early. It reports false if the b does not begin with
+// a valid element header.
+func derElement(b []byte) (tag byte, content []byte, n uint64, ok bool) {
+	if len(b) < 2 || b[0]&0x1f == 0x1f { // No high tag numbers
+		return 0, nil, 0, false
+	}
+
+	hl, l := uint64(2), uint64(b[1])
+	if b[1]&0x80 != 0 {
+		ll := uint64(b[1] & 0x7f)
+		if ll == 0 || ll > 4 || uint64(len(b)) < 2+ll {
+			return 0, nil, 0, false
+		}
+
+		l = 0
+		for _, c := range b[2 : 2+ll] {
+			l = l<<8 | uint64(c)
+		}
+
+		if l < 0x80 {
+			return 0, nil, 0, false // Not the shortest encoding
+		}
+
+		hl += ll
+	}
+
+	end := hl + l
+	if end > uint64(len(b)) {
+		end = uint64(len(b))
+	}
+
+	return b[0], b[hl:end], hl + l, true
+}
+
+// ebmlElement returns the ID and the data of the EBML element at the beginning
+// of the b, along with the length of the whole element. The data is truncated
+// if the b ends early, and extends to the end of the b if its size is unknown.
+func ebmlElement(b []byte) (id uint64, data []byte, n int, ok bool) {
+	id, i := ebmlVint(b, true)
+	if i == 0 {
+		return 0, nil, 0, false
+	}
+
+	size, j := ebmlVint(b[i:], false)
+	if j == 0 {
+		return 0, nil, 0, false
+	}
+
+	start := i + j
+	if size == ^uint64(0) || size > uint64(len(b)-start) {
+		return id, b[start:], len(b), true
+	}
+
+	n = start + int(size)
+
+	return id, b[start:n], n, true
+}
+
+// ebmlVint returns the value and the length of the EBML variable-length
+// integer at the beginning of the b. The length descriptor is kept in the value
+// if the marker is true, as it is for element IDs. A value with all data bits
+// set means unknown and is returned as the maximum uint64.
+func ebmlVint(b []byte, marker bool) (uint64, int) {
+	if len(b) == 0 || b[0] == 0 {
+		return 0, 0
+	}
+
+	n := 1
+	for b[0]&(0x80>>uint(n-1)) == 0 {
+		n++
+	}
+
+	if n > len(b) {
+		return 0, 0
+	}
+
+	v := uint64(b[0])
+	if !marker {
+		v &= 0xff >> uint(n)
+	}
+
+	for _, c := range b[1:n] {
+		v = v<<8 | uint64(c)
+	}
+
+	if !marker && v == 1<<uint(7*n)-1 {
+		return ^uint64(0), n
+	}
+
+	return v, n
+}
+
+// ivfFourCC returns the FourCC of the codec used in the IVF b.
+func ivfFourCC(b []byte) []byte {
+	if len(b) < 32 ||
+		b[0] != 0x44 ||
+		b[1] != 0x4b ||
+		b[2] != 0x49 ||
+		b[3] != 0x46 ||
+		b[4] != 0x00 ||
+		b[5] != 0x00 ||
+		b[6] != 0x20 ||
+		b[7] != 0x00 {
+		return nil
+	}
+
+	return b[8:12]
+}
+
+// iWorkApplication returns the name of the iWork application ("keynote",
+// "numbers" or "pages") that created the ZIP archive with the entries, or "" if
+// it is not an iWork document.
+func iWorkApplication(entries []zipEntry) string {
+	iwa := false
+	for _, e := range entries {
+		name := string(e.name)
+		switch {
+		case name == "index.apxl",
+			strings.HasPrefix(name, "Index/Slide"),
+			strings.HasPrefix(name, "Index/MasterSlide"):
+			return "keynote"
+		case name == "Index/CalculationEngine.iwa",
+			strings.HasPrefix(name, "Index/Tables/"):
+			return "numbers"
+		case name == "index.xml":
+			d := zipEntryData(e)
+			switch {
+			case bytes.Contains(
+				d,
+				[]byte("http://developer.apple.com/namespaces/sl"),
+			):
+				return "pages"
+			case bytes.Contains(
+				d,
+				[]byte("http://developer.apple.com/namespaces/ls"),
+			):
+				return "numbers"
+			}
+		case name == "Index/Document.iwa":
+			iwa = true
+		}
+	}
+
+	if iwa {
+		return "pages"
+	}
+
+	return ""
+}
+
+// m3u reports whether the lines are the lines of an extended M3U.
+func m3u(lines [][]byte) bool {
+	return len(lines) > 0 &&
+		bytes.Equal(bytes.TrimRight(lines[0], " \t"), []byte("#EXTM3U"))
+}
+
+// mailHeaders returns the number of well-known RFC 5322 header fields found in
+// the header section at the beginning of the lines. It returns 0 if the lines
+// do not begin with a header section.
+func mailHeaders(lines [][]byte) int {
+	known := 0
+	for i, l := range lines {
+		if len(l) == 0 {
+			break
+		}
+
+		if l[0] == ' ' || l[0] == '\t' {
+			if i == 0 {
+				return 0
+			}
+
+			continue
+		}
+
+		j := bytes.IndexByte(l, ':')
+		if j <= 0 {
+			return 0
+		}
+
+		for _, c := range l[:j] {
+			if c <= ' ' || c > '~' {
+				return 0
+			}
+		}
+
+		switch strings.ToLower(string(l[:j])) {
+		case "cc",
+			"content-transfer-encoding",
+			"content-type",
+			"date",
+			"delivered-to",
+			"dkim-signature",
+			"from",
+			"in-reply-to",
+			"message-id",
+			"mime-version",
+			"received",
+			"references",
+			"reply-to",
+			"return-path",
+			"sender",
+			"subject",
+			"to":
+			known++
+		}
+	}
+
+	return known
+}
+
+// matroskaInfo returns the DocType of the Matroska b and reports whether all
+// the tracks found in the b are audio tracks.
+func matroskaInfo(b []byte) (docType string, audioOnly bool) {
+	id, data, n, ok := ebmlElement(b)
+	if !ok || id != 0x1a45dfa3 {
+		return "", false
+	}
+
+	for len(data) > 0 {
+		id, d, n, ok := ebmlElement(data)
+		if !ok {
+			break
+		}
+
+		if id == 0x4282 {
+			docType = string(d)
+		}
+
+		data = data[n:]
+	}
+
+	if id, data, _, ok = ebmlElement(b[n:]); !ok || id != 0x18538067 {
+		return docType, false
+	}
+
+	var trackTypes []byte
+	for len(data) > 0 {
+		id, d, n, ok := ebmlElement(data)
+		if !ok || id == 0x1f43b675 { // Cluster
+			break
+		}
+
+		if id == 0x1654ae6b { // Tracks
+			for len(d) > 0 {
+				id, e, n, ok := ebmlElement(d)
+				if !ok {
+					break
+				}
+
+				for id == 0xae && len(e) > 0 { // TrackEntry
+					id, f, n, ok := ebmlElement(e)
+					if !ok {
+						break
+					}
+
+					if id == 0x83 && len(f) == 1 { // TrackType
+						trackTypes = append(trackTypes, f[0])
+					}
+
+					e = e[n:]
+				}
+
+				d = d[n:]
+			}
+		}
+
+		data = data[n:]
+	}
+
+	for _, t := range trackTypes {
+		if t != 0x02 {
+			return docType, false
+		}
+	}
+
+	return docType, len(trackTypes) > 0
+}
+
+// mobiRecord0 returns the first record of the Mobipocket e-book b, which holds
+// the PalmDOC and MOBI headers. It returns nil if the b is not a Mobipocket
+// e-book.
+func mobiRecord0(b []byte) []byte {
+	if len(b) < 86 || string(b[60:68]) != "BOOKMOBI" {
+		return nil
+	}
+
+	n := binary.BigEndian.Uint16(b[76:78])
+	start := uint64(binary.BigEndian.Uint32(b[78:82]))
+	end := uint64(len(b))
+	if n > 1 && len(b) >= 90 {
+		if next := uint64(binary.BigEndian.Uint32(b[86:90])); next < end {
+			end = next
+		}
+	}
+
+	if n == 0 || start >= end {
+		return nil
+	}
+
+	return b[start:end]
+}
+
+// mpegFrameLength returns the length of the MPEG audio frame whose header is at
+// the beginning of the b. It returns zero if the header is invalid.
+func mpegFrameLength(b []byte) int {
+	if len(b) < 4 || b[0] != 0xff || b[1]&0xe0 != 0xe0 {
+		return 0
+	}
+
+	version := b[1] >> 3 & 0x03 // 0: MPEG-2.5, 2: MPEG-2, 3: MPEG-1
+	layer := b[1] >> 1 & 0x03   // 1: Layer III, 2: Layer II, 3: Layer I
+	bitrateIndex := b[2] >> 4
+	sampleRateIndex := b[2] >> 2 & 0x03
+	padding := int(b[2] >> 1 & 0x01)
+	if version == 1 ||
+		layer == 0 ||
+		bitrateIndex == 0 ||
+		bitrateIndex == 15 ||
+		sampleRateIndex == 3 {
+		return 0
+	}
+
+	bitrates := [...][14]int{
+		{32, 64, 96, 128, 160, 192, 224, 256, 288, 320, 352, 384, 416, 448},
+		{32, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320, 384},
+		{32, 40, 48, 56, 64, 80, 96, 112, 128, 160, 192, 224, 256, 320},
+		{32, 48, 56, 64, 80, 96, 112, 128, 144, 160, 176, 192, 224, 256},
+		{8, 16, 24, 32, 40, 48, 56, 64, 80, 96, 112, 128, 144, 160},
+	}
+
+	sampleRates := [...][3]int{
+		{11025, 12000, 8000},
+		{},
+		{22050, 24000, 16000},
+		{44100, 48000, 32000},
+	}
+
+	var row int
+	switch {
+	case version == 3:
+		row = int(3 - layer)
+	case layer == 3:
+		row = 3
+	default:
+		row = 4
+	}
+
+	bitrate := bitrates[row][bitrateIndex-1] * 1000
+	sampleRate := sampleRates[version][sampleRateIndex]
+	switch {
+	case layer == 3:
+		return (12*bitrate/sampleRate + padding) * 4
+	case layer == 1 && version != 3:
+		return 72*bitrate/sampleRate + padding
+	}
+
+	return 144*bitrate/sampleRate + padding
+}
+
+// ooxmlApplication returns the name of the Office application ("excel",
+// "powerpoint" or "word") that created the OOXML (Office Open XML) package with
+// the entries, or "" if it is not an OOXML document.
+func ooxmlApplication(entries []zipEntry) string {
+	pkg, app := false, ""
+	for _, e := range entries {
+		name := string(e.name)
+		switch {
+		case name == "[Content_Types].xml", name == "_rels/.rels":
+			pkg = true
+		case app != "":
+		case strings.HasPrefix(name, "ppt/"):
+			app = "powerpoint"
+		case strings.HasPrefix(name, "word/"):
+			app = "word"
+		case strings.HasPrefix(name, "xl/"):
+			app = "excel"
+		}
+	}
+
+	if !pkg {
+		return ""
+	}
+
+	return app
+}
+
+// zipContains reports whether the entries contain any entry with one of the
+// names.
+func zipContains(entries []zipEntry, names ...string) bool {
+	for _, e := range entries {
+		for _, name := range names {
+			if string(e.name) == name {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// zipEntry is a local file entry of a ZIP archive.
+type zipEntry struct {
+	name   []byte
+	extra  []byte
+	method uint16
+	data   []byte
+}
+
+// zipMimeType returns the content of the stored "mimetype" entry found in the
+// entries, as used by the OpenDocument and EPUB containers. It returns "" if
+// there is no such entry.
+func zipMimeType(entries []zipEntry) string {
+	for _, e := range entries {
+		if string(e.name) == "mimetype" && e.method == 0 {
+			return string(bytes.TrimSpace(e.data))
+		}
+	}
+
+	return ""
+}
+
+// zipEntryData returns at most the first zipEntryDataLimit bytes of the
+// uncompressed data of the e. It returns as much as can be inflated if the data
+// of the e is truncated, or nil if the compression method of the e is not
+// supported.
+func zipEntryData(e zipEntry) []byte {
+	switch e.method {
+	case 0: // Stored
+		if len(e.data) > zipEntryDataLimit {
+			return e.data[:zipEntryDataLimit]
+		}
+
+		return e.data
+	case 8: // Deflated
+		d, _ := ioutil.ReadAll(io.LimitReader(
+			flate.NewReader(bytes.NewReader(e.data)),
+			zipEntryDataLimit,
+		))
+		return d
+	}
+
+	return nil
+}
+
+// zipEntries returns at most the first zipEntriesLimit local file entries
+// found in the first zipEntriesWindow bytes of the ZIP b. The data of the last
+// entry may be truncated if the b ends early.
+func zipEntries(b []byte) []zipEntry {
+	sign := []byte{'P', 'K', 0x03, 0x04}
+	if len(b) < 30 || !bytes.Equal(b[:4], sign) {
+		return nil
+	}
+
+	if len(b) > zipEntriesWindow {
+		b = b[:zipEntriesWindow]
+	}
+
+	entries := make([]zipEntry, 0, zipEntriesLimit)
+	for len(b) >= 30 &&
+		bytes.Equal(b[:4], sign) &&
+		len(entries) < zipEntriesLimit {
+		flags := binary.LittleEndian.Uint16(b[6:8])
+		size := binary.LittleEndian.Uint32(b[18:22])
+		nameEnd := 30 + int(binary.LittleEndian.Uint16(b[26:28]))
+		extraEnd := nameEnd + int(binary.LittleEndian.Uint16(b[28:30]))
+		if nameEnd > len(b) {
+			break
+		}
+
+		e := zipEntry{
+			name:   b[30:nameEnd],
+			method: binary.LittleEndian.Uint16(b[8:10]),
+		}
+
+		if extraEnd > len(b) {
+			e.extra = b[nameEnd:]
+			entries = append(entries, e)
+			break
+		}
+
+		e.extra = b[nameEnd:extraEnd]
+		b = b[extraEnd:]
+
+		if flags&0x08 != 0 && size == 0 { // Sizes in data descriptor
+			i := bytes.Index(b, sign)
+			if i < 0 {
+				i = bytes.Index(b, []byte{'P', 'K', 0x01, 0x02})
+			}
+
+			if i < 0 {
+				e.data = b
+				entries = append(entries, e)
+				break
+			}
+
+			e.data = b[:i]
+			for _, n := range []int{16, 24} {
+				if len(e.data) >= n && bytes.HasPrefix(
+					e.data[len(e.data)-n:],
+					[]byte{'P', 'K', 0x07, 0x08},
+				) {
+					e.data = e.data[:len(e.data)-n]
+					break
+				}
+			}
+
+			entries = append(entries, e)
+			b = b[i:]
+
+			continue
+		}
+
+		if uint64(size) > uint64(len(b)) {
+			e.data = b
+			entries = append(entries, e)
+			break
+		}
+
+		e.data = b[:size]
+		entries = append(entries, e)
+		b = b[size:]
+
+		if flags&0x08 != 0 {
+			if bytes.HasPrefix(b, []byte{'P', 'K', 0x07, 0x08}) {
+				b = b[4:]
+			}
+
+			if len(b) < 12 {
+				break
+			}
+
+			b = b[12:]
+		}
+	}
+
+	return entries
 }